@@ -0,0 +1,68 @@
+package forecast
+
+import (
+	"context"
+	"sort"
+)
+
+// Severity is how urgently an alert should be treated.
+type Severity string
+
+const (
+	Advisory Severity = "advisory"
+	Watch    Severity = "watch"
+	Warning  Severity = "warning"
+)
+
+// rank orders severities from least (0) to most urgent. Unknown values rank lowest.
+func (s Severity) rank() int {
+	switch s {
+	case Warning:
+		return 3
+	case Watch:
+		return 2
+	case Advisory:
+		return 1
+	}
+	return 0
+}
+
+// SortedAlerts returns a copy of the alerts ordered by severity (warning > watch > advisory)
+// and then by issue time, newest first. Alerts that compare equal keep their original order.
+func (f *Forecast) SortedAlerts() []Alert {
+	alerts := make([]Alert, len(f.Alerts))
+	copy(alerts, f.Alerts)
+	sort.SliceStable(alerts, func(i, j int) bool {
+		ri, rj := alerts[i].Severity.rank(), alerts[j].Severity.rank()
+		if ri != rj {
+			return ri > rj
+		}
+		return alerts[i].Time > alerts[j].Time
+	})
+	return alerts
+}
+
+// GetAlerts fetches the active alerts for a location using a default Client for key.
+func GetAlerts(key string, lat string, long string) ([]Alert, error) {
+	return NewClient(key).GetAlerts(lat, long)
+}
+
+// GetAlerts fetches only the active alerts for a location, excluding every other block on
+// the server to keep the response small. It returns an empty, non-nil slice when there are
+// no alerts.
+func (c *Client) GetAlerts(lat string, long string) ([]Alert, error) {
+	q := query{
+		lat:     lat,
+		long:    long,
+		time:    Now,
+		exclude: []DataBlockType{Currently, Minutely, Hourly, Daily, FlagData},
+	}
+	f, _, err := c.get(context.Background(), q)
+	if err != nil {
+		return nil, err
+	}
+	if f.Alerts == nil {
+		return []Alert{}, nil
+	}
+	return f.Alerts, nil
+}
@@ -0,0 +1,60 @@
+package forecast
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultBatchConcurrency is the request limit used by GetBatchStream when
+// Client.BatchConcurrency is unset.
+const DefaultBatchConcurrency = 4
+
+// BatchResult is the outcome of one request made by GetBatchStream. Index is the position
+// of its location in the slice passed to GetBatchStream.
+type BatchResult struct {
+	Index    int
+	Forecast *Forecast
+	Err      error
+}
+
+// GetBatchStream fetches current forecasts for locations using a default Client for key.
+func GetBatchStream(ctx context.Context, key string, locations []LatLong, units Units) <-chan BatchResult {
+	return NewClient(key).GetBatchStream(ctx, locations, units)
+}
+
+// GetBatchStream fetches the current forecast for every location, at most BatchConcurrency
+// at a time, and sends each result on the returned channel as soon as it completes, so
+// results arrive in completion order rather than input order. The channel is closed once
+// every location has been handled, or once ctx is cancelled: in-flight requests are then
+// aborted and locations that have not been fetched yet are skipped without a result.
+func (c *Client) GetBatchStream(ctx context.Context, locations []LatLong, units Units) <-chan BatchResult {
+	out := make(chan BatchResult)
+	sem := make(chan struct{}, c.batchConcurrency())
+	var wg sync.WaitGroup
+	for i, ll := range locations {
+		wg.Add(1)
+		go func(i int, ll LatLong) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			defer func() { <-sem }()
+			if ctx.Err() != nil {
+				return
+			}
+
+			f, _, err := c.get(ctx, query{lat: ll.lat(), long: ll.long(), time: Now, units: units})
+			select {
+			case out <- BatchResult{Index: i, Forecast: f, Err: err}:
+			case <-ctx.Done():
+			}
+		}(i, ll)
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
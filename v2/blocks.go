@@ -0,0 +1,119 @@
+package forecast
+
+import "encoding/json"
+
+// AvailableBlocks returns the blocks that carry data after decoding, in the order
+// currently, minutely, hourly, daily, alerts, flags. Blocks that were excluded from the
+// request or omitted by the provider are left out.
+func (f *Forecast) AvailableBlocks() []DataBlockType {
+	var blocks []DataBlockType
+	if f.Currently.Time != 0 {
+		blocks = append(blocks, Currently)
+	}
+	if len(f.Minutely.Data) > 0 {
+		blocks = append(blocks, Minutely)
+	}
+	if len(f.Hourly.Data) > 0 {
+		blocks = append(blocks, Hourly)
+	}
+	if len(f.Daily.Data) > 0 {
+		blocks = append(blocks, Daily)
+	}
+	if len(f.Alerts) > 0 {
+		blocks = append(blocks, Alerts)
+	}
+	if f.Flags.Units != "" || len(f.Flags.Sources) > 0 {
+		blocks = append(blocks, FlagData)
+	}
+	return blocks
+}
+
+// HasBlock reports whether block b carries data, as listed by AvailableBlocks. A block can
+// be missing because it was excluded from the request or because the provider does not
+// cover it at the forecast's location.
+func (f *Forecast) HasBlock(b DataBlockType) bool {
+	for _, have := range f.AvailableBlocks() {
+		if have == b {
+			return true
+		}
+	}
+	return false
+}
+
+// MinutelyAvailable reports whether the forecast has minute-by-minute data. The API
+// reports no flag for it: outside the areas covered by precipitation radar, which is
+// mostly North America, the minutely block is simply omitted, so this is the check to make
+// before showing a minute-by-minute view.
+func (f *Forecast) MinutelyAvailable() bool {
+	return f.HasBlock(Minutely)
+}
+
+// FromJSONBlocks decodes jsonBlob like FromJSON but only populates the given blocks;
+// the others are skipped during decoding and never allocated. Location, timezone, flags and
+// other top-level fields are always decoded, since they are small and needed to resolve units.
+func FromJSONBlocks(jsonBlob []byte, blocks ...DataBlockType) (*Forecast, error) {
+	var f Forecast
+	type alias Forecast
+	aux := struct {
+		*alias
+		Currently blockDecoder `json:"currently"`
+		Minutely  blockDecoder `json:"minutely"`
+		Hourly    blockDecoder `json:"hourly"`
+		Daily     blockDecoder `json:"daily"`
+		Alerts    blockDecoder `json:"alerts"`
+	}{alias: (*alias)(&f)}
+	for _, b := range blocks {
+		switch b {
+		case Currently:
+			aux.Currently.dst = &f.Currently
+		case Minutely:
+			aux.Minutely.dst = &f.Minutely
+		case Hourly:
+			aux.Hourly.dst = &f.Hourly
+		case Daily:
+			aux.Daily.dst = &f.Daily
+		case Alerts:
+			aux.Alerts.dst = &f.Alerts
+		}
+	}
+	err := json.Unmarshal(jsonBlob, &aux)
+	if err != nil {
+		return nil, err
+	}
+	f.normalizeTimes()
+	f.setUnits(f.ResolvedUnits().unitSet())
+
+	return &f, nil
+}
+
+// blockDecoder decodes a block into dst, or discards it when dst is nil.
+type blockDecoder struct {
+	dst interface{}
+}
+
+func (d *blockDecoder) UnmarshalJSON(b []byte) error {
+	if d.dst == nil {
+		return nil
+	}
+	return json.Unmarshal(b, d.dst)
+}
+
+// Blocks returns the forecast's non-empty data point blocks keyed by type. Currently is
+// included as a block holding its single point; alerts and flags are not data point blocks
+// and are never included.
+func (f *Forecast) Blocks() map[DataBlockType]DataBlock {
+	blocks := make(map[DataBlockType]DataBlock)
+	if f.Currently.Time != 0 {
+		blocks[Currently] = DataBlock{
+			Summary: f.Currently.Summary,
+			Icon:    f.Currently.Icon,
+			Data:    []DataPoint{f.Currently},
+		}
+	}
+	for b, db := range map[DataBlockType]DataBlock{Minutely: f.Minutely, Hourly: f.Hourly, Daily: f.Daily} {
+		if len(db.Data) > 0 {
+			blocks[b] = db
+		}
+	}
+	return blocks
+}
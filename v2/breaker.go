@@ -0,0 +1,110 @@
+package forecast
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without contacting the API while a Client's CircuitBreaker is open.
+var ErrCircuitOpen = errors.New("forecast: circuit breaker open")
+
+// BreakerState is the state of a CircuitBreaker.
+type BreakerState int
+
+const (
+	// BreakerClosed lets every request through.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen fails every request fast until the cooldown has elapsed.
+	BreakerOpen
+	// BreakerHalfOpen lets a single trial request through to probe the API.
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return "closed"
+}
+
+// CircuitBreaker stops a Client from sending requests while the API appears to be down.
+// After Threshold consecutive failures (transport errors, 429 or 5xx responses) the circuit
+// opens and requests fail with ErrCircuitOpen. Once Cooldown has passed a single trial
+// request is let through: success closes the circuit, failure opens it for another cooldown.
+// A CircuitBreaker is safe for concurrent use and may be shared by several clients.
+type CircuitBreaker struct {
+	// Threshold is the number of consecutive failures that opens the circuit. Defaults to 5.
+	Threshold int
+
+	// Cooldown is how long the circuit stays open before a trial. Defaults to 30 seconds.
+	Cooldown time.Duration
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	trial    bool
+}
+
+// State returns the current state of the breaker.
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+func (b *CircuitBreaker) threshold() int {
+	if b.Threshold > 0 {
+		return b.Threshold
+	}
+	return 5
+}
+
+func (b *CircuitBreaker) cooldown() time.Duration {
+	if b.Cooldown > 0 {
+		return b.Cooldown
+	}
+	return 30 * time.Second
+}
+
+// allow reports whether a request may be sent at now.
+func (b *CircuitBreaker) allow(now time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case BreakerOpen:
+		if now.Sub(b.openedAt) < b.cooldown() {
+			return ErrCircuitOpen
+		}
+		b.state = BreakerHalfOpen
+		b.trial = true
+		return nil
+	case BreakerHalfOpen:
+		if b.trial {
+			return ErrCircuitOpen
+		}
+		b.trial = true
+	}
+	return nil
+}
+
+// record updates the breaker with the outcome of a request sent at now.
+func (b *CircuitBreaker) record(failed bool, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+	if !failed {
+		b.state = BreakerClosed
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold() {
+		b.state = BreakerOpen
+		b.openedAt = now
+	}
+}
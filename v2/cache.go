@@ -0,0 +1,176 @@
+package forecast
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// historyAge is how far in the past a Time Machine request must be before its response is
+// treated as final. A request returns the whole local day around the requested time, so a
+// margin of two days guarantees that day has fully elapsed in every time zone.
+const historyAge = 48 * time.Hour
+
+// Cache keeps decoded forecasts in memory, keyed by the canonical request URL without the
+// API key, which includes the coordinates, time and every query parameter.
+// Expired entries whose response carried an ETag or Last-Modified header are revalidated
+// with a conditional request, and reused without decoding when the server answers
+// 304 Not Modified.
+// A Cache holds at most MaxEntries forecasts.
+// A Cache is safe for concurrent use by multiple Clients.
+type Cache struct {
+	// CurrentTTL is how long responses for current conditions and future times are kept.
+	CurrentTTL time.Duration
+
+	// HistoryTTL is how long Time Machine responses for past days are kept.
+	// Historical data does not change, so zero keeps them until they are evicted.
+	HistoryTTL time.Duration
+
+	// MaxEntries is the number of forecasts kept. When a new one is stored in a full cache,
+	// expired entries that cannot be revalidated are dropped first and, if that is not
+	// enough, the entry stored longest ago. Defaults to DefaultCacheMaxEntries.
+	MaxEntries int
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	forecast *Forecast
+	stored   time.Time
+	expires  time.Time // zero means never
+
+	// Validators from the response, used to revalidate the entry once it expires.
+	etag         string
+	lastModified string
+}
+
+// DefaultCacheMaxEntries is the size limit used when Cache.MaxEntries is unset.
+const DefaultCacheMaxEntries = 1000
+
+// NewCache returns a Cache that keeps current conditions for ten minutes and
+// historical responses until they are evicted.
+func NewCache() *Cache {
+	return &Cache{CurrentTTL: 10 * time.Minute}
+}
+
+// get returns the entry for key. fresh reports whether it has not yet expired.
+// Expired entries are only kept, and returned with ok set, when they carry an ETag or
+// Last-Modified validator that can be used to revalidate them.
+// Callers must not modify the returned forecast.
+func (c *Cache) get(key string, now time.Time) (e cacheEntry, fresh bool, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok = c.entries[key]
+	if !ok {
+		return cacheEntry{}, false, false
+	}
+	if e.expires.IsZero() || !now.After(e.expires) {
+		return e, true, true
+	}
+	if e.etag == "" && e.lastModified == "" {
+		delete(c.entries, key)
+		return cacheEntry{}, false, false
+	}
+	return e, false, true
+}
+
+// set stores a copy of f under key, choosing the TTL from the request's time parameter and
+// keeping the ETag and Last-Modified validators from header.
+func (c *Cache) set(key string, requestTime string, f *Forecast, header http.Header, now time.Time) {
+	historical := isHistorical(requestTime, now)
+	ttl := c.CurrentTTL
+	if historical {
+		ttl = c.HistoryTTL
+	}
+	var expires time.Time
+	switch {
+	case ttl > 0:
+		expires = now.Add(ttl)
+	case !historical:
+		// Current data is never kept without a TTL.
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[string]cacheEntry)
+	}
+	e := cacheEntry{
+		forecast:     f.Clone(),
+		stored:       now,
+		expires:      expires,
+		etag:         header.Get("ETag"),
+		lastModified: header.Get("Last-Modified"),
+	}
+	if old, ok := c.entries[key]; ok {
+		// A 304 response need not repeat the validators.
+		if e.etag == "" {
+			e.etag = old.etag
+		}
+		if e.lastModified == "" {
+			e.lastModified = old.lastModified
+		}
+	} else {
+		c.makeRoom(now)
+	}
+	c.entries[key] = e
+}
+
+// makeRoom evicts entries until there is room for one more. The caller must hold c.mu.
+func (c *Cache) makeRoom(now time.Time) {
+	limit := c.MaxEntries
+	if limit <= 0 {
+		limit = DefaultCacheMaxEntries
+	}
+	if len(c.entries) < limit {
+		return
+	}
+	for key, e := range c.entries {
+		if !e.expires.IsZero() && now.After(e.expires) && e.etag == "" && e.lastModified == "" {
+			delete(c.entries, key)
+		}
+	}
+	for len(c.entries) >= limit {
+		var oldest string
+		for key, e := range c.entries {
+			if oldest == "" || e.stored.Before(c.entries[oldest].stored) {
+				oldest = key
+			}
+		}
+		delete(c.entries, oldest)
+	}
+}
+
+// Purge removes every entry from the cache.
+func (c *Cache) Purge() {
+	c.mu.Lock()
+	c.entries = nil
+	c.mu.Unlock()
+}
+
+// isHistorical reports whether a Time Machine time refers to a day that has fully elapsed.
+// Times that cannot be parsed are treated as current.
+func isHistorical(requestTime string, now time.Time) bool {
+	t, ok := parseRequestTime(requestTime)
+	return ok && t.Before(now.Add(-historyAge))
+}
+
+// parseRequestTime parses the time parameter of a request, which is either a UNIX timestamp
+// or an ISO 8601 date-time with an optional zone offset.
+func parseRequestTime(s string) (time.Time, bool) {
+	if isCurrent(s) {
+		return time.Time{}, false
+	}
+	if sec, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(sec, 0), true
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05-0700", "2006-01-02T15:04:05"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
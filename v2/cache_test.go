@@ -0,0 +1,39 @@
+package forecast
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCacheMaxEntries(t *testing.T) {
+	c := &Cache{CurrentTTL: time.Minute, MaxEntries: 2}
+	now := time.Unix(1450000000, 0)
+	f := &Forecast{Latitude: 1}
+
+	// An expired entry without validators goes first, even though it is not the oldest.
+	c.set("a", Now, f, http.Header{}, now)
+	c.set("b", Now, f, http.Header{"Etag": {`"x"`}}, now.Add(time.Second))
+	c.set("c", Now, f, http.Header{}, now.Add(2*time.Minute))
+	if _, _, ok := c.get("b", now.Add(2*time.Minute)); !ok {
+		t.Error("revalidatable entry b was evicted")
+	}
+	if _, ok := c.entries["a"]; ok {
+		t.Error("expired entry a was kept")
+	}
+
+	// With nothing expired to drop, the entry stored longest ago is evicted.
+	c.set("d", Now, f, http.Header{}, now.Add(2*time.Minute+time.Second))
+	if len(c.entries) != 2 {
+		t.Fatalf("cache holds %d entries, want 2", len(c.entries))
+	}
+	if _, ok := c.entries["b"]; ok {
+		t.Error("oldest entry b was kept")
+	}
+
+	// Replacing an existing entry evicts nothing.
+	c.set("d", Now, f, http.Header{}, now.Add(3*time.Minute))
+	if _, ok := c.entries["c"]; !ok || len(c.entries) != 2 {
+		t.Errorf("replacing an entry evicted another: %v", c.entries)
+	}
+}
@@ -0,0 +1,123 @@
+package forecast
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CalendarEvent is a calendar entry derived from a forecast.
+type CalendarEvent struct {
+	Start       time.Time
+	End         time.Time
+	Title       string
+	Description string
+	URI         string
+}
+
+// Events returns sunrise and sunset events for every day of the daily block and one event
+// per alert, spanning from its issue time to its expiry, ordered by start time in the
+// forecast's time zone. Sun events are instantaneous (Start equals End); days without a
+// sunrise or sunset, such as during polar night, contribute no event for it. Alerts without
+// an expiry last one hour.
+func (f *Forecast) Events() []CalendarEvent {
+	loc := f.Location()
+	var events []CalendarEvent
+	for _, dp := range f.Daily.Data {
+		if dp.SunriseTime != 0 {
+			t := unixTime(dp.SunriseTime, loc)
+			events = append(events, CalendarEvent{Start: t, End: t, Title: "Sunrise"})
+		}
+		if dp.SunsetTime != 0 {
+			t := unixTime(dp.SunsetTime, loc)
+			events = append(events, CalendarEvent{Start: t, End: t, Title: "Sunset"})
+		}
+	}
+	for _, a := range f.Alerts {
+		start := unixTime(a.Time, loc)
+		end := unixTime(a.Expires, loc)
+		if end.IsZero() {
+			end = start.Add(time.Hour)
+		}
+		events = append(events, CalendarEvent{
+			Start:       start,
+			End:         end,
+			Title:       a.Title,
+			Description: a.Description,
+			URI:         a.URI,
+		})
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Start.Before(events[j].Start)
+	})
+	return events
+}
+
+// WriteICal writes the forecast's Events to w as an iCalendar (RFC 5545) document.
+func (f *Forecast) WriteICal(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	line := func(s string) {
+		// Lines longer than 75 octets are folded onto continuation lines starting with a space.
+		for len(s) > 75 {
+			cut := 75
+			for cut > 0 && !isRuneStart(s[cut]) {
+				cut--
+			}
+			bw.WriteString(s[:cut] + "\r\n")
+			s = " " + s[cut:]
+		}
+		bw.WriteString(s + "\r\n")
+	}
+
+	// The forecast's own time stands in for the creation time so output is reproducible.
+	// Without current conditions, the earliest data point or event is used instead.
+	events := f.Events()
+	start := unixTime(f.Currently.Time, time.UTC)
+	if start.IsZero() {
+		if first, _, ok := f.CoveredRange(); ok {
+			start = first
+		} else if len(events) > 0 {
+			start = events[0].Start
+		}
+	}
+	stamp := start.UTC().Format(icalTime)
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//forecast//Go//EN")
+	for i, e := range events {
+		line("BEGIN:VEVENT")
+		line(fmt.Sprintf("UID:%d-%d@forecast", e.Start.Unix(), i))
+		line("DTSTAMP:" + stamp)
+		line("DTSTART:" + e.Start.UTC().Format(icalTime))
+		// Instantaneous events omit DTEND, which must otherwise be later than DTSTART.
+		if e.End.After(e.Start) {
+			line("DTEND:" + e.End.UTC().Format(icalTime))
+		}
+		line("SUMMARY:" + icalEscape(e.Title))
+		if e.Description != "" {
+			line("DESCRIPTION:" + icalEscape(e.Description))
+		}
+		if e.URI != "" {
+			line("URL:" + e.URI)
+		}
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	return bw.Flush()
+}
+
+const icalTime = "20060102T150405Z"
+
+var icalEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func icalEscape(s string) string {
+	return icalEscaper.Replace(s)
+}
+
+// isRuneStart reports whether b begins a UTF-8 encoded rune, so folding never splits one.
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
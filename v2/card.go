@@ -0,0 +1,75 @@
+package forecast
+
+// CardDays is the number of days in the mini-forecast of a CardSpec.
+const CardDays = 5
+
+// CardSpec holds what a social card or similar image shows, ready for a renderer: every
+// value is formatted with its unit, using the forecast's units.
+type CardSpec struct {
+	// Location is the forecast's PlaceName, which is empty unless a ReverseGeocoder
+	// supplied it; callers may fill in a name of their own.
+	Location    string
+	Temperature string
+	Summary     string
+	Icon        string
+	Emoji       string
+	High        string
+	Low         string
+	Days        []CardDay
+}
+
+// CardDay is one day of a CardSpec's mini-forecast.
+type CardDay struct {
+	// Weekday is the abbreviated day name in the forecast's time zone, such as "Mon".
+	Weekday string
+	Icon    string
+	Emoji   string
+	High    string
+	Low     string
+}
+
+// CardData is CardDataWith using the default precisions.
+func (f *Forecast) CardData() CardSpec {
+	return f.CardDataWith(nil)
+}
+
+// CardDataWith extracts a CardSpec from the forecast: the current temperature, summary and
+// icon, today's high and low, and up to CardDays days starting today. Temperatures are
+// formatted with FormatFieldWith and prec. Values that are absent are left empty.
+func (f *Forecast) CardDataWith(prec map[string]int) CardSpec {
+	loc := f.Location()
+	unit := f.TempUnit()
+	temp := func(field string, v float64, present bool) string {
+		if !present {
+			return ""
+		}
+		return FormatFieldWith(prec, field, v) + unit
+	}
+
+	cur := f.Currently
+	c := CardSpec{
+		Location:    f.PlaceName,
+		Temperature: temp("temperature", cur.Temperature, cur.Time != 0),
+		Summary:     cur.Summary,
+		Icon:        cur.Icon,
+	}
+	if cur.Icon != "" {
+		c.Emoji = cur.IconEmoji()
+	}
+	for i, dp := range f.Daily.Limit(CardDays).Data {
+		high := temp("temperatureHigh", dp.High(), dp.hasHigh())
+		low := temp("temperatureLow", dp.Low(), dp.hasLow())
+		if i == 0 {
+			c.High, c.Low = high, low
+		}
+		d := CardDay{Icon: dp.Icon, High: high, Low: low}
+		if dp.Time != 0 {
+			d.Weekday = unixTime(dp.Time, loc).Format("Mon")
+		}
+		if dp.Icon != "" {
+			d.Emoji = dp.IconEmoji()
+		}
+		c.Days = append(c.Days, d)
+	}
+	return c
+}
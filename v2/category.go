@@ -0,0 +1,53 @@
+package forecast
+
+// DayCategory is a coarse classification of a day's weather, as returned by
+// DataPoint.DayCategory.
+type DayCategory string
+
+const (
+	DaySunny  DayCategory = "sunny"
+	DayCloudy DayCategory = "cloudy"
+	DayRainy  DayCategory = "rainy"
+	DaySnowy  DayCategory = "snowy"
+	DayStormy DayCategory = "stormy"
+	DayFoggy  DayCategory = "foggy"
+)
+
+// DefaultDayCategoryPrecipProbability is the precipitation probability from which
+// DayCategory classifies a day as rainy or snowy regardless of its icon.
+const DefaultDayCategoryPrecipProbability = 0.5
+
+// DayCategory is DayCategoryWith using DefaultDayCategoryPrecipProbability.
+func (dp DataPoint) DayCategory() DayCategory {
+	return dp.DayCategoryWith(DefaultDayCategoryPrecipProbability)
+}
+
+// DayCategoryWith classifies the point, normally a daily one, into a single category. When
+// several apply, the first matching rule wins:
+//
+//  1. DayStormy: the icon is Thunderstorm, Tornado or Hail.
+//  2. DaySnowy: the icon is Snow or Sleet, or snow or sleet is expected with at least
+//     precipProbability.
+//  3. DayRainy: the icon is Rain, or rain, or precipitation of unknown type, is expected
+//     with at least precipProbability.
+//  4. DayFoggy: the icon is Fog.
+//  5. DayCloudy: the icon is Cloudy, or CloudCover is at least 0.6.
+//  6. DaySunny: anything else, including the clear, partly cloudy and wind icons.
+func (dp DataPoint) DayCategoryWith(precipProbability float64) DayCategory {
+	icon := Icon(dp.Icon)
+	likely := dp.PrecipProbability >= precipProbability
+	precip := PrecipType(dp.PrecipType)
+	switch {
+	case icon == Thunderstorm || icon == Tornado || icon == Hail:
+		return DayStormy
+	case icon == Snow || icon == Sleet || likely && (precip == PrecipSnow || precip == PrecipSleet):
+		return DaySnowy
+	case icon == Rain || likely && (precip == PrecipRain || precip == ""):
+		return DayRainy
+	case icon == Fog:
+		return DayFoggy
+	case icon == Cloudy || dp.CloudCover >= 0.6:
+		return DayCloudy
+	}
+	return DaySunny
+}
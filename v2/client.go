@@ -0,0 +1,546 @@
+package forecast
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client issues forecast requests for a single API key.
+// Its exported fields may be changed before the first request is made.
+type Client struct {
+	Key string
+
+	// BaseURL is the endpoint requests are sent to. Defaults to BASEURL.
+	BaseURL string
+
+	// HTTPClient is used to send requests. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+
+	// Method is the HTTP method used for requests. Defaults to GET.
+	// Gateways that only accept other methods can set it together with Body.
+	Method string
+
+	// Body, when non-nil, is sent with every request using BodyType as its Content-Type.
+	Body     []byte
+	BodyType string
+
+	// Cache, when non-nil, is consulted before every Get and stores its results.
+	Cache *Cache
+
+	// Units is used by methods that do not take units explicitly.
+	// Empty leaves the choice to the API, which defaults to US.
+	Units Units
+
+	// Geocoder resolves place names for ForecastByName.
+	Geocoder Geocoder
+
+	// ReverseGeocoder, when non-nil, fills in Forecast.PlaceName of every forecast fetched
+	// by Get and its variants, before it is cached.
+	ReverseGeocoder ReverseGeocoder
+
+	// Logger receives request and response diagnostics, with the API key redacted.
+	// Requests are logged at debug level and transport failures at error level, using the
+	// request's context so that attributes carried by it are included. Defaults to
+	// discarding everything.
+	Logger *slog.Logger
+
+	// Keys, when non-nil, supplies the API key for Get and its variants instead of Key,
+	// rotating across several keys.
+	Keys *KeyPool
+
+	// Breaker, when non-nil, stops requests from being sent while the API is failing.
+	Breaker *CircuitBreaker
+
+	// Metrics, when non-nil, records every request.
+	Metrics Metrics
+
+	// Unwrap, when non-nil, is applied to every response body before it is decoded, for
+	// gateways that wrap the forecast in an envelope. See UnwrapField.
+	Unwrap func(body []byte) ([]byte, error)
+
+	// Lenient disables the checks that reject a decoded response carrying an error body
+	// (see APIError) or no forecast data at all (see ErrEmptyForecast), returning whatever
+	// was decoded instead.
+	Lenient bool
+
+	// Sanitize zeroes physically impossible values, such as the -999 sentinels some
+	// providers use for missing data, in every decoded response. See Forecast.Sanitized
+	// for the ranges applied.
+	Sanitize bool
+
+	// Now is the clock used for cache expiry, key rotation and the circuit breaker.
+	// Defaults to time.Now; tests can substitute a fixed clock. Helpers on Forecast and
+	// DataBlock that depend on the current time take it as a parameter instead, and new
+	// time-dependent code should follow one of these two patterns rather than call
+	// time.Now directly.
+	Now func() time.Time
+
+	// MaxResponseSize caps the number of bytes read from a response body.
+	// Defaults to DefaultMaxResponseSize.
+	MaxResponseSize int64
+
+	// HistoryConcurrency is the maximum number of Time Machine requests GetHistory issues
+	// at once. Defaults to DefaultHistoryConcurrency.
+	HistoryConcurrency int
+
+	// BatchConcurrency is the maximum number of requests GetBatchStream issues at once.
+	// Defaults to DefaultBatchConcurrency.
+	BatchConcurrency int
+
+	// DataPointHook, when non-nil, is called for every data point of each decoded response:
+	// Currently first, then the minutely, hourly and daily points in order. It runs after
+	// the body has been decoded, its units recorded and, with Sanitize, its values cleaned,
+	// and before the response is checked and cached, so cached forecasts hold the hooked
+	// values. Use it to normalize or enrich points in one place.
+	DataPointHook func(*DataPoint)
+
+	// Coalesce makes concurrent identical requests share a single API call: while a request
+	// is in flight, Get and its variants wait for it instead of sending their own, and all
+	// receive its result or error, each with its own copy of the forecast. Requests are
+	// identical when their URLs match apart from the key. Cancelling the context of the
+	// request in flight fails it for every caller waiting on it.
+	Coalesce bool
+
+	flights flightGroup
+}
+
+// DefaultMaxResponseSize is the response body limit used when Client.MaxResponseSize is unset.
+// Full forecasts are typically well under 100KB.
+const DefaultMaxResponseSize = 10 << 20
+
+// NewClient returns a Client that sends plain GET requests to BASEURL.
+func NewClient(key string) *Client {
+	return &Client{Key: key}
+}
+
+func (c *Client) baseURL() string {
+	if c.BaseURL != "" {
+		return c.BaseURL
+	}
+	return BASEURL
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+func (c *Client) maxResponseSize() int64 {
+	if c.MaxResponseSize > 0 {
+		return c.MaxResponseSize
+	}
+	return DefaultMaxResponseSize
+}
+
+func (c *Client) historyConcurrency() int {
+	if c.HistoryConcurrency > 0 {
+		return c.HistoryConcurrency
+	}
+	return DefaultHistoryConcurrency
+}
+
+func (c *Client) batchConcurrency() int {
+	if c.BatchConcurrency > 0 {
+		return c.BatchConcurrency
+	}
+	return DefaultBatchConcurrency
+}
+
+func (c *Client) now() time.Time {
+	if c.Now != nil {
+		return c.Now()
+	}
+	return time.Now()
+}
+
+func (c *Client) method() string {
+	if c.Method != "" {
+		return c.Method
+	}
+	return http.MethodGet
+}
+
+// Get fetches and decodes the forecast for the given coordinates.
+// time is either Now (or empty) for current conditions or a Time Machine timestamp.
+func (c *Client) Get(lat string, long string, time string, units Units) (*Forecast, error) {
+	f, _, err := c.GetWithResponse(lat, long, time, units)
+	return f, err
+}
+
+// GetWithResponse is like Get but also returns the HTTP response, so that its status and
+// headers can be inspected. The body has already been read and closed. The response is also
+// returned when decoding the body fails, and is nil when the request could not be sent or
+// the forecast was served from the Cache without contacting the API.
+func (c *Client) GetWithResponse(lat string, long string, time string, units Units) (*Forecast, *http.Response, error) {
+	return c.get(context.Background(), query{lat: lat, long: long, time: time, units: units})
+}
+
+// Fetch fetches and decodes the forecast for the given coordinates as configured by opts.
+// Without options it requests current conditions in the client's Units.
+func (c *Client) Fetch(lat string, long string, opts ...RequestOption) (*Forecast, error) {
+	q := query{lat: lat, long: long, time: Now, units: c.Units}
+	for _, opt := range opts {
+		err := opt(&q)
+		if err != nil {
+			return nil, err
+		}
+	}
+	f, _, err := c.get(context.Background(), q)
+	return f, err
+}
+
+// ForecastBoth fetches the current forecast once, in SI units, and returns it together
+// with a US copy derived from it with ConvertTo rather than fetched, saving an API call
+// over two requests. The two forecasts share no data.
+func (c *Client) ForecastBoth(lat string, long string) (us *Forecast, si *Forecast, err error) {
+	si, err = c.Fetch(lat, long, WithUnits(SI))
+	if err != nil {
+		return nil, nil, err
+	}
+	return si.ConvertTo(US), si, nil
+}
+
+// get fetches the forecast described by q, consulting the cache first. Cancelling ctx
+// aborts the request.
+func (c *Client) get(ctx context.Context, q query) (*Forecast, *http.Response, error) {
+	// Responses do not depend on the key, so it is left out of the cache key.
+	cacheKey := q.url(c.baseURL(), "")
+	var stale *cacheEntry
+	if c.Cache != nil {
+		e, fresh, ok := c.Cache.get(cacheKey, c.now())
+		if fresh {
+			f := e.forecast.Clone()
+			f.FromCache = true
+			return f, nil, nil
+		}
+		if ok {
+			stale = &e
+		}
+	}
+
+	fetch := func() (*Forecast, *http.Response, error) {
+		for {
+			key, err := c.key()
+			if err != nil {
+				return nil, nil, err
+			}
+			f, res, err := c.fetch(ctx, q, key, cacheKey, stale)
+			if err == errKeyRejected {
+				continue
+			}
+			return f, res, err
+		}
+	}
+	if c.Coalesce {
+		return c.flights.do(cacheKey, fetch)
+	}
+	return fetch()
+}
+
+// errKeyRejected is returned by fetch when a key from the client's KeyPool was rejected
+// and another one should be tried.
+var errKeyRejected = errors.New("forecast: key rejected")
+
+// fetch performs a single request for q with key, revalidating stale if it is non-nil.
+func (c *Client) fetch(ctx context.Context, q query, key string, cacheKey string, stale *cacheEntry) (*Forecast, *http.Response, error) {
+	req, err := c.newRequest(ctx, q.url(c.baseURL(), key))
+	if err != nil {
+		return nil, nil, err
+	}
+	if stale != nil {
+		if stale.etag != "" {
+			req.Header.Set("If-None-Match", stale.etag)
+		}
+		if stale.lastModified != "" {
+			req.Header.Set("If-Modified-Since", stale.lastModified)
+		}
+	}
+	res, err := c.do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+
+	if c.Keys != nil && (res.StatusCode == http.StatusForbidden || res.StatusCode == http.StatusTooManyRequests) {
+		io.Copy(io.Discard, res.Body)
+		c.Keys.exhaust(key, c.now())
+		return nil, res, errKeyRejected
+	}
+
+	if res.StatusCode == http.StatusNotModified && stale != nil {
+		io.Copy(io.Discard, res.Body)
+		f := stale.forecast.Clone()
+		f.APICalls = apiCalls(res.Header, f.APICalls)
+		c.Cache.set(cacheKey, q.time, f, res.Header, c.now())
+		f.FromCache = true
+		return f, res, nil
+	}
+
+	if err := errorStatus(res); err != nil {
+		return nil, res, err
+	}
+	f, err := c.decodeResponse(res)
+	if err != nil {
+		return nil, res, err
+	}
+	c.setPlaceName(f)
+
+	calls := apiCalls(res.Header, f.APICalls)
+	f.APICalls = calls
+	if c.Keys != nil {
+		c.Keys.record(key, calls)
+	}
+	if c.Metrics != nil {
+		c.Metrics.SetAPICalls(calls)
+	}
+
+	if c.Cache != nil {
+		c.Cache.set(cacheKey, q.time, f, res.Header, c.now())
+	}
+
+	return f, res, nil
+}
+
+// DoRequest sends req, which the caller has built in full, and decodes the response as a
+// forecast. It bypasses the URL builder, the Cache and the Keys pool entirely, for gateways
+// that need custom authentication, signing or headers; the Breaker, Logger, Metrics, Unwrap
+// and Lenient settings still apply.
+func (c *Client) DoRequest(req *http.Request) (*Forecast, error) {
+	res, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if err := errorStatus(res); err != nil {
+		return nil, err
+	}
+	f, err := c.decodeResponse(res)
+	if err != nil {
+		return nil, err
+	}
+	calls := apiCalls(res.Header, f.APICalls)
+	f.APICalls = calls
+	if c.Metrics != nil {
+		c.Metrics.SetAPICalls(calls)
+	}
+	return f, nil
+}
+
+// errorStatus discards the body of a response with a status outside 2xx and returns the
+// error checkStatus maps it to, so that error pages, even JSON ones, are never decoded or
+// cached as forecasts. It returns nil for 2xx responses.
+func errorStatus(res *http.Response) error {
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return nil
+	}
+	io.Copy(io.Discard, res.Body)
+	return checkStatus(res)
+}
+
+// apiCalls returns the number of API calls reported by the X-Forecast-API-Calls header, or
+// fallback, the count decoded from the body, when the header is absent or malformed.
+func apiCalls(h http.Header, fallback int) int {
+	if calls, err := strconv.Atoi(h.Get("X-Forecast-API-Calls")); err == nil {
+		return calls
+	}
+	return fallback
+}
+
+// key returns the API key for the next request.
+func (c *Client) key() (string, error) {
+	if c.Keys != nil {
+		return c.Keys.pick(c.now())
+	}
+	return c.Key, nil
+}
+
+// GetResponse issues the forecast request and returns the raw response.
+// The caller is responsible for closing the response body.
+func (c *Client) GetResponse(lat string, long string, time string, units Units) (*http.Response, error) {
+	return c.send(context.Background(), query{lat: lat, long: long, time: time, units: units}.url(c.baseURL(), c.Key))
+}
+
+func (c *Client) send(ctx context.Context, url string) (*http.Response, error) {
+	req, err := c.newRequest(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(req)
+}
+
+// do sends req, logging the request and its outcome with the API key redacted.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	log := c.logger()
+	ctx := req.Context()
+	url := c.redact(req.URL.String())
+	if c.Breaker != nil {
+		if err := c.Breaker.allow(c.now()); err != nil {
+			log.DebugContext(ctx, "forecast request rejected", "method", req.Method, "url", url, "error", err)
+			return nil, err
+		}
+	}
+	start := time.Now()
+	log.DebugContext(ctx, "forecast request", "method", req.Method, "url", url)
+
+	res, err := c.httpClient().Do(req)
+	if c.Breaker != nil {
+		failed := err != nil || res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500
+		c.Breaker.record(failed, c.now())
+	}
+	if c.Metrics != nil {
+		status := 0
+		if res != nil {
+			status = res.StatusCode
+		}
+		c.Metrics.ObserveRequest(time.Since(start), status, err)
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "forecast request failed", "method", req.Method, "url", url, "error", c.redact(err.Error()))
+		return nil, err
+	}
+	log.DebugContext(ctx, "forecast response",
+		"method", req.Method,
+		"url", url,
+		"status", res.StatusCode,
+		"duration", time.Since(start),
+	)
+	return res, nil
+}
+
+func (c *Client) logger() *slog.Logger {
+	if c.Logger != nil {
+		return c.Logger
+	}
+	return discardLogger
+}
+
+var discardLogger = slog.New(slog.DiscardHandler)
+
+// redact removes the client's API keys, raw and as escaped in URLs, from s.
+func (c *Client) redact(s string) string {
+	keys := []string{c.Key}
+	if c.Keys != nil {
+		keys = append(keys, c.Keys.keys...)
+	}
+	return redactKeys(s, keys)
+}
+
+// redactKeys replaces every key in keys, raw and as escaped in request URLs, with
+// "REDACTED" in s.
+func redactKeys(s string, keys []string) string {
+	for _, k := range keys {
+		if k != "" {
+			s = strings.Replace(s, k, "REDACTED", -1)
+			s = strings.Replace(s, escapeSegment(k), "REDACTED", -1)
+		}
+	}
+	return s
+}
+
+func (c *Client) newRequest(ctx context.Context, url string) (*http.Request, error) {
+	var body io.Reader
+	if c.Body != nil {
+		body = bytes.NewReader(c.Body)
+	}
+	req, err := http.NewRequestWithContext(ctx, c.method(), url, body)
+	if err != nil {
+		return nil, err
+	}
+	if c.Body != nil && c.BodyType != "" {
+		req.Header.Set("Content-Type", c.BodyType)
+	}
+	return req, nil
+}
+
+// Ping checks that the API is reachable and accepts the client's key. It requests a fixed
+// coordinate with every data block excluded so the response is as small as possible,
+// although it still counts as one API call. It returns ErrInvalidKey if the key is
+// rejected and a *StatusError for any other non-200 response.
+func (c *Client) Ping() error {
+	q := query{
+		lat:     "0",
+		long:    "0",
+		time:    Now,
+		exclude: []DataBlockType{Currently, Minutely, Hourly, Daily, Alerts, FlagData},
+	}
+	res, err := c.send(context.Background(), q.url(c.baseURL(), c.Key))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, res.Body)
+
+	return checkStatus(res)
+}
+
+// decodeResponse reads and decodes the body of res, distinguishing empty and truncated
+// bodies from malformed ones.
+func (c *Client) decodeResponse(res *http.Response) (*Forecast, error) {
+	limit := c.maxResponseSize()
+	body, err := io.ReadAll(io.LimitReader(res.Body, limit+1))
+	if int64(len(body)) > limit {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrResponseTooLarge, limit)
+	}
+	if err == io.ErrUnexpectedEOF {
+		return nil, fmt.Errorf("%w: connection closed after %d bytes", ErrTruncatedResponse, len(body))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(body) == 0 {
+		return nil, ErrEmptyResponse
+	}
+	if res.ContentLength > 0 && int64(len(body)) < res.ContentLength {
+		return nil, fmt.Errorf("%w: read %d of %d bytes", ErrTruncatedResponse, len(body), res.ContentLength)
+	}
+
+	if c.Unwrap != nil {
+		body, err = c.Unwrap(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	f, err := FromJSON(body)
+	if err == nil && c.Sanitize {
+		if n := f.sanitize(); n > 0 {
+			c.logger().Debug("forecast sanitized out-of-range values", "count", n)
+		}
+	}
+	if err == nil && c.DataPointHook != nil {
+		c.DataPointHook(&f.Currently)
+		for _, db := range []*DataBlock{&f.Minutely, &f.Hourly, &f.Daily} {
+			for i := range db.Data {
+				c.DataPointHook(&db.Data[i])
+			}
+		}
+	}
+	var syntaxErr *json.SyntaxError
+	switch {
+	case err == nil && c.Lenient:
+		return f, nil
+	case err == nil:
+		if f.Latitude == 0 && f.Longitude == 0 && (f.Code != 0 || f.Error != "") {
+			return nil, &APIError{Code: f.Code, Message: f.Error}
+		}
+		if f.isEmpty() {
+			return nil, ErrEmptyForecast
+		}
+		return f, nil
+	case errors.As(err, &syntaxErr) && syntaxErr.Offset >= int64(len(body)):
+		return nil, fmt.Errorf("%w: %v", ErrTruncatedResponse, err)
+	}
+	return nil, fmt.Errorf("%w: %v", ErrInvalidJSON, err)
+}
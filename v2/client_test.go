@@ -0,0 +1,129 @@
+package forecast
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// serve returns a Client whose requests are answered with body by a test server.
+func serve(t *testing.T, header http.Header, body string) *Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for k, v := range header {
+			w.Header()[k] = v
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	c := NewClient("key")
+	c.BaseURL = srv.URL
+	return c
+}
+
+func TestAPIErrorDetection(t *testing.T) {
+	const (
+		errorBody = `{"code":400,"error":"The given location is invalid."}`
+		validBody = `{"latitude":37.8267,"longitude":-122.423,"currently":{"time":1450000000,"temperature":50}}`
+	)
+	tests := []struct {
+		name     string
+		body     string
+		lenient  bool
+		apiError bool
+	}{
+		{"error body", errorBody, false, true},
+		{"error body, lenient", errorBody, true, false},
+		{"valid body", validBody, false, false},
+		{"valid body, lenient", validBody, true, false},
+	}
+	for _, tt := range tests {
+		c := serve(t, nil, tt.body)
+		c.Lenient = tt.lenient
+		f, err := c.Get("37.8267", "-122.423", Now, US)
+
+		var apiErr *APIError
+		if got := errors.As(err, &apiErr); got != tt.apiError {
+			t.Errorf("%s: got error %v, want APIError %v", tt.name, err, tt.apiError)
+			continue
+		}
+		if tt.apiError {
+			if apiErr.Code != 400 || apiErr.Message != "The given location is invalid." {
+				t.Errorf("%s: got %+v", tt.name, apiErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+			continue
+		}
+		if tt.lenient && tt.body == errorBody && (f.Code != 400 || f.Error == "") {
+			t.Errorf("%s: lenient client dropped the error fields: %+v", tt.name, f)
+		}
+	}
+}
+
+func TestAPICalls(t *testing.T) {
+	const body = `{"latitude":1,"longitude":2,"currently":{"time":1450000000},"apicalls":7}`
+	tests := []struct {
+		name   string
+		header http.Header
+		want   int
+	}{
+		{"header", http.Header{"X-Forecast-Api-Calls": {"42"}}, 42},
+		{"no header", nil, 7},
+		{"malformed header", http.Header{"X-Forecast-Api-Calls": {"many"}}, 7},
+	}
+	for _, tt := range tests {
+		f, err := serve(t, tt.header, body).Get("1", "2", Now, US)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if f.APICalls != tt.want {
+			t.Errorf("%s: APICalls = %d, want %d", tt.name, f.APICalls, tt.want)
+		}
+	}
+}
+
+func TestErrorStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		want   error // nil for a *StatusError
+	}{
+		{"forbidden", http.StatusForbidden, ErrInvalidKey},
+		{"unauthorized", http.StatusUnauthorized, ErrInvalidKey},
+		{"server error", http.StatusInternalServerError, nil},
+	}
+	for _, tt := range tests {
+		var requests int
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(tt.status)
+			w.Write([]byte(`{"latitude":1,"longitude":2,"currently":{"time":1450000000}}`))
+		}))
+		c := NewClient("key")
+		c.BaseURL = srv.URL
+		c.Cache = NewCache()
+
+		for i := 0; i < 2; i++ {
+			f, err := c.Get("1", "2", Now, US)
+			if f != nil {
+				t.Errorf("%s: decoded an error response: %+v", tt.name, f)
+			}
+			var statusErr *StatusError
+			switch {
+			case tt.want != nil && !errors.Is(err, tt.want):
+				t.Errorf("%s: got error %v, want %v", tt.name, err, tt.want)
+			case tt.want == nil && (!errors.As(err, &statusErr) || statusErr.StatusCode != tt.status):
+				t.Errorf("%s: got error %v, want a StatusError for %d", tt.name, err, tt.status)
+			}
+		}
+		if requests != 2 {
+			t.Errorf("%s: sent %d requests, want 2 since errors are not cached", tt.name, requests)
+		}
+		srv.Close()
+	}
+}
@@ -0,0 +1,72 @@
+package forecast
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// errFlightPanicked is returned, wrapped with the panic value, to callers that were waiting
+// on a coalesced request whose caller panicked.
+var errFlightPanicked = errors.New("forecast: coalesced request panicked")
+
+// flightGroup coalesces concurrent identical requests, in the manner of
+// golang.org/x/sync/singleflight, for Client.Coalesce.
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// flight is a request in progress and, once wg is done, its outcome.
+type flight struct {
+	wg  sync.WaitGroup
+	f   *Forecast
+	res *http.Response
+	err error
+}
+
+// do calls fn for key unless a call for key is already in progress, in which case it waits
+// for that call and shares its outcome. Every caller receives its own copy of the forecast,
+// so none can modify another's; the response, whose body has already been consumed, is
+// shared. If fn panics, the panic propagates to the caller that ran it, while the callers
+// waiting on it receive an error instead of hanging or crashing.
+func (g *flightGroup) do(key string, fn func() (*Forecast, *http.Response, error)) (*Forecast, *http.Response, error) {
+	g.mu.Lock()
+	if g.flights == nil {
+		g.flights = make(map[string]*flight)
+	}
+	fl, ok := g.flights[key]
+	if !ok {
+		fl = &flight{}
+		fl.wg.Add(1)
+		g.flights[key] = fl
+	}
+	g.mu.Unlock()
+
+	if ok {
+		fl.wg.Wait()
+	} else {
+		func() {
+			defer func() {
+				r := recover()
+				if r != nil {
+					fl.f, fl.res, fl.err = nil, nil, fmt.Errorf("%w: %v", errFlightPanicked, r)
+				}
+				g.mu.Lock()
+				delete(g.flights, key)
+				g.mu.Unlock()
+				fl.wg.Done()
+				if r != nil {
+					panic(r)
+				}
+			}()
+			fl.f, fl.res, fl.err = fn()
+		}()
+	}
+
+	if fl.f == nil {
+		return nil, fl.res, fl.err
+	}
+	return fl.f.Clone(), fl.res, fl.err
+}
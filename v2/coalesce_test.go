@@ -0,0 +1,52 @@
+package forecast
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestFlightGroupPanic(t *testing.T) {
+	var g flightGroup
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	leader := make(chan any)
+	go func() {
+		defer func() { leader <- recover() }()
+		g.do("k", func() (*Forecast, *http.Response, error) {
+			close(started)
+			<-release
+			panic("boom")
+		})
+	}()
+	<-started
+
+	waiter := make(chan error)
+	go func() {
+		_, _, err := g.do("k", func() (*Forecast, *http.Response, error) {
+			t.Error("waiter ran its own request")
+			return nil, nil, nil
+		})
+		waiter <- err
+	}()
+	// Give the waiter time to join the flight before the leader panics.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	if r := <-leader; r != "boom" {
+		t.Errorf("leader recovered %v, want the original panic", r)
+	}
+	if err := <-waiter; !errors.Is(err, errFlightPanicked) {
+		t.Errorf("waiter got error %v, want %v", err, errFlightPanicked)
+	}
+
+	// The failed flight must not linger.
+	f, _, err := g.do("k", func() (*Forecast, *http.Response, error) {
+		return &Forecast{Latitude: 1}, nil, nil
+	})
+	if err != nil || f.Latitude != 1 {
+		t.Errorf("got %+v, %v after the panic, want a fresh request", f, err)
+	}
+}
@@ -0,0 +1,196 @@
+package forecast
+
+import "math"
+
+// heatIndexF returns the NWS heat index for a temperature in °F and a relative humidity
+// between 0 and 1, using the Rothfusz regression with the NWS low-humidity and
+// high-humidity adjustments. It is only meaningful from about 80°F upwards.
+func heatIndexF(t, humidity float64) float64 {
+	rh := humidity * 100
+	simple := 0.5 * (t + 61 + (t-68)*1.2 + rh*0.094)
+	if (simple+t)/2 < 80 {
+		return simple
+	}
+	hi := -42.379 + 2.04901523*t + 10.14333127*rh - 0.22475541*t*rh -
+		0.00683783*t*t - 0.05481717*rh*rh + 0.00122874*t*t*rh +
+		0.00085282*t*rh*rh - 0.00000199*t*t*rh*rh
+	switch {
+	case rh < 13 && t >= 80 && t <= 112:
+		hi -= (13 - rh) / 4 * math.Sqrt((17-math.Abs(t-95))/17)
+	case rh > 85 && t >= 80 && t <= 87:
+		hi += (rh - 85) / 10 * (87 - t) / 5
+	}
+	return hi
+}
+
+// windChillF returns the NWS wind chill for a temperature in °F and a wind speed in mph.
+// It is only defined for temperatures at or below 50°F and winds above 3 mph.
+func windChillF(t, mph float64) float64 {
+	v := math.Pow(mph, 0.16)
+	return 35.74 + 0.6215*t - 35.75*v + 0.4275*t*v
+}
+
+// ComputeApparentTemperature estimates the apparent ("feels like") temperature from
+// Temperature, Humidity and WindSpeed, in the point's own units, for providers that do not
+// report ApparentTemperature.
+// It uses the NWS wind chill formula (2001) when the air is at or below 50°F (10°C) with
+// wind above 3 mph, the NWS heat index (Rothfusz regression with its adjustments) at or
+// above 80°F (27°C) when humidity is known, and the air temperature otherwise.
+func (dp DataPoint) ComputeApparentTemperature() float64 {
+	return convertUnit(dp.feelsLikeF(), Fahrenheit, dp.unitSet().temperature)
+}
+
+// FeelsLike returns ApparentTemperature, falling back to ComputeApparentTemperature
+// when the provider left it unset.
+func (dp DataPoint) FeelsLike() float64 {
+	if dp.ApparentTemperature != 0 {
+		return dp.ApparentTemperature
+	}
+	return dp.ComputeApparentTemperature()
+}
+
+// feelsLikeF combines temperature, humidity and wind into a single °F value, using the heat
+// index or wind chill where they apply and the air temperature otherwise.
+func (dp DataPoint) feelsLikeF() float64 {
+	u := dp.unitSet()
+	t := u.toFahrenheit(dp.Temperature)
+	mph := u.toMPH(dp.WindSpeed)
+	switch {
+	case t >= 80 && dp.Humidity > 0:
+		return heatIndexF(t, dp.Humidity)
+	case t <= 50 && mph > 3:
+		return windChillF(t, mph)
+	}
+	return t
+}
+
+// ComfortLevel classifies how the conditions feel to a person outdoors. It returns one of
+// "dangerous heat" (heat index of 103°F/39°C or more), "oppressive" (heat index of
+// 90°F/32°C or more), "humid" (dew point of 65°F/18°C or more), "frigid" (wind chill of
+// 14°F/-10°C or less), "cold" (below 50°F/10°C) or "comfortable".
+// Thresholds follow the NWS heat index and wind chill categories and are applied in the
+// point's own unit system.
+func (dp DataPoint) ComfortLevel() string {
+	feels := dp.feelsLikeF()
+	switch {
+	case feels >= 103:
+		return "dangerous heat"
+	case feels >= 90:
+		return "oppressive"
+	case dp.unitSet().toFahrenheit(dp.DewPoint) >= 65:
+		return "humid"
+	case feels <= 14:
+		return "frigid"
+	case feels < 50:
+		return "cold"
+	}
+	return "comfortable"
+}
+
+// clothingBands lists ClothingSuggestion's garments from warmest weather to coldest, each
+// with the lowest apparent temperature in °F it applies to.
+var clothingBands = []struct {
+	minF    float64
+	clothes string
+}{
+	{80, "t-shirt and shorts"},
+	{65, "t-shirt"},
+	{55, "light jacket"},
+	{45, "jacket"},
+	{32, "warm coat"},
+	{math.Inf(-1), "heavy coat + gloves"},
+}
+
+// ClothingSuggestion returns what to wear outdoors in the point's conditions. The garment is
+// chosen from the apparent temperature (see FeelsLike):
+//
+//	80°F (27°C) and above  t-shirt and shorts
+//	65°F (18°C) and above  t-shirt
+//	55°F (13°C) and above  light jacket
+//	45°F (7°C) and above   jacket
+//	32°F (0°C) and above   warm coat
+//	below 32°F (0°C)       heavy coat + gloves
+//
+// Sustained wind of 20 mph (32 km/h) or more moves the suggestion one band colder when the
+// apparent temperature is 50°F (10°C) or above, where wind chill does not already account
+// for it. When PrecipProbability is 0.5 or more, " + umbrella" is appended, or " + boots"
+// for snow. Thresholds are applied in the point's own unit system.
+func (dp DataPoint) ClothingSuggestion() string {
+	u := dp.unitSet()
+	feels := u.toFahrenheit(dp.FeelsLike())
+	band := 0
+	for band < len(clothingBands)-1 && feels < clothingBands[band].minF {
+		band++
+	}
+	if feels >= 50 && u.toMPH(dp.WindSpeed) >= 20 {
+		band++
+	}
+
+	s := clothingBands[band].clothes
+	if dp.PrecipProbability >= 0.5 {
+		if PrecipType(dp.PrecipType) == PrecipSnow {
+			s += " + boots"
+		} else {
+			s += " + umbrella"
+		}
+	}
+	return s
+}
+
+// runningPenalty is the pace penalty, in percent, of the temperature plus dew point rule
+// used by RunningAdjustment, at each sum in °F. Penalties are interpolated in between.
+var runningPenalty = []struct{ sum, pct float64 }{
+	{100, 0},
+	{110, 0.5},
+	{120, 1},
+	{130, 2},
+	{140, 3},
+	{150, 4.5},
+	{160, 6},
+	{170, 8},
+	{180, 10},
+}
+
+// RunningAdjustment estimates how much heat and humidity slow a runner down. It returns the
+// apparent temperature (see FeelsLike) in the point's units, and the expected slowdown as a
+// percentage of pace, using the widely published rule that adds the air temperature and
+// the dew point in °F: up to 100 there is no penalty, rising to 0.5% at 110, 1% at 120, 2%
+// at 130, 3% at 140, 4.5% at 150, 6% at 160, 8% at 170 and 10% at 180, interpolated in
+// between. When the dew point is absent it is estimated from Temperature and Humidity.
+// Above 180, where hard running is not advised at all, and for points without a temperature
+// and humidity, such as daily ones, both results are zero.
+func (dp DataPoint) RunningAdjustment() (feelsLike float64, pacePenaltyPct float64) {
+	if dp.isDaily() || dp.Humidity <= 0 {
+		return 0, 0
+	}
+	u := dp.unitSet()
+	t := u.toFahrenheit(dp.Temperature)
+	dew := u.toFahrenheit(dp.DewPoint)
+	if dp.DewPoint == 0 {
+		dew = convertUnit(dewPointC(convertUnit(t, Fahrenheit, Celsius), dp.Humidity), Celsius, Fahrenheit)
+	}
+	sum := t + dew
+	last := runningPenalty[len(runningPenalty)-1]
+	if sum > last.sum {
+		return 0, 0
+	}
+	feelsLike = dp.FeelsLike()
+	for i := 1; i < len(runningPenalty); i++ {
+		lo, hi := runningPenalty[i-1], runningPenalty[i]
+		if sum <= hi.sum {
+			if sum > lo.sum {
+				pacePenaltyPct = lo.pct + (sum-lo.sum)/(hi.sum-lo.sum)*(hi.pct-lo.pct)
+			}
+			break
+		}
+	}
+	return feelsLike, pacePenaltyPct
+}
+
+// dewPointC estimates the dew point in °C from a temperature in °C and a relative humidity
+// between 0 and 1, using the Magnus formula.
+func dewPointC(t, humidity float64) float64 {
+	const b, c = 17.62, 243.12
+	g := math.Log(humidity) + b*t/(c+t)
+	return c * g / (b - g)
+}
@@ -0,0 +1,91 @@
+package forecast
+
+// Side identifies which of two compared forecasts a Comparison favours.
+type Side int
+
+const (
+	Tie Side = iota
+	SideA
+	SideB
+)
+
+// ComparedValue is one measurement of two forecasts side by side.
+type ComparedValue struct {
+	A float64
+	B float64
+}
+
+// Higher returns the side with the higher value.
+func (v ComparedValue) Higher() Side {
+	switch {
+	case v.A > v.B:
+		return SideA
+	case v.B > v.A:
+		return SideB
+	}
+	return Tie
+}
+
+// Lower returns the side with the lower value.
+func (v ComparedValue) Lower() Side {
+	switch v.Higher() {
+	case SideA:
+		return SideB
+	case SideB:
+		return SideA
+	}
+	return Tie
+}
+
+// Comparison holds two forecasts' conditions for today side by side, all in the first
+// forecast's units, as returned by CompareForecasts.
+type Comparison struct {
+	// TempUnit and WindUnit name the units of the temperatures and wind speeds.
+	TempUnit string
+	WindUnit string
+
+	// Temperature, WindSpeed and CloudCover are the current conditions.
+	Temperature ComparedValue
+	WindSpeed   ComparedValue
+	CloudCover  ComparedValue
+
+	// High, Low and PrecipProbability come from the first day of the daily block.
+	High              ComparedValue
+	Low               ComparedValue
+	PrecipProbability ComparedValue
+
+	// Warmer has the higher current temperature, Drier the lower chance of precipitation
+	// today and Calmer the lower current wind speed.
+	Warmer Side
+	Drier  Side
+	Calmer Side
+}
+
+// CompareForecasts compares today's conditions at two locations. b is converted to a's
+// units first, so forecasts fetched in different unit systems compare correctly. Values a
+// forecast lacks, such as the daily ones when it has no daily block, compare as zero.
+func CompareForecasts(a, b *Forecast) Comparison {
+	b = b.convert(a.unitSet())
+	var aDay, bDay DataPoint
+	if len(a.Daily.Data) > 0 {
+		aDay = a.Daily.Data[0]
+	}
+	if len(b.Daily.Data) > 0 {
+		bDay = b.Daily.Data[0]
+	}
+
+	c := Comparison{
+		TempUnit:          a.TempUnit(),
+		WindUnit:          a.WindUnit(),
+		Temperature:       ComparedValue{a.Currently.Temperature, b.Currently.Temperature},
+		WindSpeed:         ComparedValue{a.Currently.WindSpeed, b.Currently.WindSpeed},
+		CloudCover:        ComparedValue{a.Currently.CloudCover, b.Currently.CloudCover},
+		High:              ComparedValue{aDay.High(), bDay.High()},
+		Low:               ComparedValue{aDay.Low(), bDay.Low()},
+		PrecipProbability: ComparedValue{aDay.PrecipProbability, bDay.PrecipProbability},
+	}
+	c.Warmer = c.Temperature.Higher()
+	c.Drier = c.PrecipProbability.Lower()
+	c.Calmer = c.WindSpeed.Lower()
+	return c
+}
@@ -0,0 +1,144 @@
+package forecast
+
+import (
+	"sort"
+	"time"
+)
+
+// PrecipProbabilityStats summarizes PrecipProbability across the block's data points.
+// ok is false, and all values zero, when the block has no data.
+func (db DataBlock) PrecipProbabilityStats() (min, max, mean float64, ok bool) {
+	if len(db.Data) == 0 {
+		return 0, 0, 0, false
+	}
+	min, max = db.Data[0].PrecipProbability, db.Data[0].PrecipProbability
+	var sum float64
+	for _, dp := range db.Data {
+		p := dp.PrecipProbability
+		if p < min {
+			min = p
+		}
+		if p > max {
+			max = p
+		}
+		sum += p
+	}
+	return min, max, sum / float64(len(db.Data)), true
+}
+
+// Sorted returns a copy of the block with its data points ordered by Time.
+// The source block is not modified.
+func (db DataBlock) Sorted() DataBlock {
+	db = db.clone()
+	sort.SliceStable(db.Data, func(i, j int) bool {
+		return db.Data[i].Time < db.Data[j].Time
+	})
+	return db
+}
+
+// dedupe drops data points whose Time equals that of the preceding point.
+// The block must already be sorted.
+func (db DataBlock) dedupe() []DataPoint {
+	var points []DataPoint
+	for i, dp := range db.Data {
+		if i > 0 && dp.Time == db.Data[i-1].Time {
+			continue
+		}
+		points = append(points, dp)
+	}
+	return points
+}
+
+// Future returns a copy of the block holding only the points whose Time is at or after now.
+// The point for the hour (or minute, or day) already in progress starts before now and is
+// therefore excluded. The source block is not modified.
+func (db DataBlock) Future(now time.Time) DataBlock {
+	return db.filter(func(dp DataPoint) bool { return dp.Time >= float64(now.Unix()) })
+}
+
+// Past returns a copy of the block holding only the points whose Time is before now,
+// the complement of Future. The source block is not modified.
+func (db DataBlock) Past(now time.Time) DataBlock {
+	return db.filter(func(dp DataPoint) bool { return dp.Time < float64(now.Unix()) })
+}
+
+// filter returns a copy of the block holding only the points for which keep returns true.
+func (db DataBlock) filter(keep func(DataPoint) bool) DataBlock {
+	out := DataBlock{Summary: db.Summary, Icon: db.Icon}
+	for _, dp := range db.Data {
+		if keep(dp) {
+			out.Data = append(out.Data, dp)
+		}
+	}
+	return out
+}
+
+// Limit returns a copy of the block holding at most its first n points, for example to show
+// a five-day outlook from the eight-day daily block. Neither the Dark Sky API nor compatible
+// providers such as Pirate Weather accept a parameter to shorten the daily block, so
+// truncation has to happen after the response is received. The source block is not modified.
+func (db DataBlock) Limit(n int) DataBlock {
+	if n < 0 {
+		n = 0
+	}
+	if n < len(db.Data) {
+		db.Data = db.Data[:n:n]
+	}
+	return db.clone()
+}
+
+// NextHighWind returns the time of the first point in the block for which IsHighWind
+// reports true with the given thresholds. ok is false if there is none. A block does not
+// know its forecast's location, so the time is in the machine's local zone; convert it with
+// In(f.Location()) for display.
+func (db DataBlock) NextHighWind(sustainedThreshold, gustThreshold float64) (at time.Time, ok bool) {
+	for _, dp := range db.Data {
+		if dp.IsHighWind(sustainedThreshold, gustThreshold) {
+			return time.Unix(int64(dp.Time), 0), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// PrecipTotal is a running precipitation accumulation total at a point in time.
+type PrecipTotal struct {
+	Time  time.Time
+	Total float64
+}
+
+// CumulativePrecip returns the running sum of PrecipAccumulation over the block's points in
+// time order, one entry per point. When types are given, only the accumulation of points
+// with one of those precipitation types is added, so CumulativePrecip(PrecipSnow) yields a
+// snowfall curve; other points repeat the previous total. As with NextHighWind, the times
+// are in the machine's local zone rather than the forecast's.
+func (db DataBlock) CumulativePrecip(types ...PrecipType) []PrecipTotal {
+	sorted := db.Sorted()
+	totals := make([]PrecipTotal, 0, len(sorted.Data))
+	var total float64
+	for _, dp := range sorted.Data {
+		if dp.hasPrecipType(types) {
+			total += dp.PrecipAccumulation
+		}
+		totals = append(totals, PrecipTotal{Time: time.Unix(int64(dp.Time), 0), Total: total})
+	}
+	return totals
+}
+
+// TotalExpectedPrecip sums ExpectedPrecip over the block, weighting each point by the hours
+// it covers: a minute for minutely points, an hour for hourly points and a day for daily
+// ones, whose intensity is an average hourly rate. The spacing is taken from the first two
+// points and defaults to an hour. The result is a liquid-equivalent amount in inches for US
+// units and millimeters otherwise (not centimeters, as PrecipAccumulation uses).
+func (db DataBlock) TotalExpectedPrecip() float64 {
+	hours := 1.0
+	if len(db.Data) > 1 {
+		if d := db.Data[1].Time - db.Data[0].Time; d > 0 {
+			hours = d / 3600
+		}
+	}
+	var total float64
+	for _, dp := range db.Data {
+		total += dp.ExpectedPrecip() * hours
+	}
+	return total
+}
@@ -0,0 +1,179 @@
+package forecast
+
+// High returns the daily high temperature, falling back to the legacy TemperatureMax field
+// when TemperatureHigh is absent. It returns 0 for points that carry neither.
+func (dp DataPoint) High() float64 {
+	if dp.TemperatureHigh != 0 || dp.TemperatureHighTime != 0 {
+		return dp.TemperatureHigh
+	}
+	return dp.TemperatureMax
+}
+
+// Low returns the overnight low temperature, falling back to the legacy TemperatureMin field
+// when TemperatureLow is absent. It returns 0 for points that carry neither.
+func (dp DataPoint) Low() float64 {
+	if dp.TemperatureLow != 0 || dp.TemperatureLowTime != 0 {
+		return dp.TemperatureLow
+	}
+	return dp.TemperatureMin
+}
+
+// hasHigh reports whether the point carries a high temperature, in either field, so that a
+// high of exactly zero can be told apart from an absent one.
+func (dp DataPoint) hasHigh() bool {
+	return dp.TemperatureHigh != 0 || dp.TemperatureHighTime != 0 ||
+		dp.TemperatureMax != 0 || dp.TemperatureMaxTime != 0
+}
+
+// hasLow is the Low counterpart of hasHigh.
+func (dp DataPoint) hasLow() bool {
+	return dp.TemperatureLow != 0 || dp.TemperatureLowTime != 0 ||
+		dp.TemperatureMin != 0 || dp.TemperatureMinTime != 0
+}
+
+// highTime returns the time of the value reported by High.
+func (dp DataPoint) highTime() float64 {
+	if dp.TemperatureHigh != 0 || dp.TemperatureHighTime != 0 {
+		return dp.TemperatureHighTime
+	}
+	return dp.TemperatureMaxTime
+}
+
+// lowTime returns the time of the value reported by Low.
+func (dp DataPoint) lowTime() float64 {
+	if dp.TemperatureLow != 0 || dp.TemperatureLowTime != 0 {
+		return dp.TemperatureLowTime
+	}
+	return dp.TemperatureMinTime
+}
+
+// isDaily reports whether dp belongs to the daily block, whose points carry sun times and
+// highs and lows instead of instantaneous temperatures.
+func (dp DataPoint) isDaily() bool {
+	return dp.SunriseTime != 0 || dp.TemperatureHighTime != 0 || dp.TemperatureMaxTime != 0
+}
+
+// PrecipType is the type of precipitation, as found in DataPoint.PrecipType.
+type PrecipType string
+
+const (
+	PrecipRain  PrecipType = "rain"
+	PrecipSnow  PrecipType = "snow"
+	PrecipSleet PrecipType = "sleet"
+)
+
+// DefaultMarginalBand is how close to freezing, in °C, a temperature must be for
+// PrecipTypeConfidence to consider the precipitation type uncertain.
+const DefaultMarginalBand = 2.0
+
+// PrecipTypeConfidence is PrecipTypeConfidenceWith using DefaultMarginalBand.
+func (dp DataPoint) PrecipTypeConfidence() (precip PrecipType, confident bool) {
+	return dp.PrecipTypeConfidenceWith(DefaultMarginalBand)
+}
+
+// PrecipTypeConfidenceWith returns the reported precipitation type and whether it can be
+// relied on. It is not confident when the temperature is within band °C (converted for US
+// units) of freezing, where rain, sleet and snow are hard to tell apart. Daily points use
+// the midpoint of their high and low.
+func (dp DataPoint) PrecipTypeConfidenceWith(band float64) (precip PrecipType, confident bool) {
+	precip = PrecipType(dp.PrecipType)
+	t := dp.Temperature
+	if dp.isDaily() {
+		t = (dp.High() + dp.Low()) / 2
+	}
+	c := convertUnit(t, dp.unitSet().temperature, Celsius)
+	return precip, c > band || c < -band
+}
+
+// IsHighWind reports whether the sustained wind reaches sustainedThreshold or the gusts
+// reach gustThreshold. Both thresholds are in the point's wind speed units; a threshold of
+// zero or less disables that check.
+func (dp DataPoint) IsHighWind(sustainedThreshold, gustThreshold float64) bool {
+	return (sustainedThreshold > 0 && dp.WindSpeed >= sustainedThreshold) ||
+		(gustThreshold > 0 && dp.WindGust >= gustThreshold)
+}
+
+// hasPrecipType reports whether the point's precipitation type is one of types.
+// An empty list matches every point.
+func (dp DataPoint) hasPrecipType(types []PrecipType) bool {
+	if len(types) == 0 {
+		return true
+	}
+	for _, t := range types {
+		if PrecipType(dp.PrecipType) == t {
+			return true
+		}
+	}
+	return false
+}
+
+// VisibilityCap is the largest visibility, in kilometers, the API reports: values are capped
+// at 10 miles, so anything at or beyond it simply means unrestricted visibility.
+const VisibilityCap = 16.09
+
+// VisibilityLevel classifies Visibility as "fog" (under 1 km), "poor" (under 4 km),
+// "moderate" (under 10 km), "good" or, at the API's cap, "excellent". The breakpoints follow
+// common meteorological usage and are applied after converting from the point's distance
+// units, which are also the units of the returned value.
+func (dp DataPoint) VisibilityLevel() (level string, value float64) {
+	km := convertUnit(dp.Visibility, dp.unitSet().distance, Kilometers)
+	switch {
+	case km < 1:
+		level = "fog"
+	case km < 4:
+		level = "poor"
+	case km < 10:
+		level = "moderate"
+	case km < VisibilityCap:
+		level = "good"
+	default:
+		level = "excellent"
+	}
+	return level, dp.Visibility
+}
+
+// TemperatureInK returns Temperature in kelvins. The source value is taken to be in the
+// point's own temperature unit, °F for US units and °C otherwise, and converted directly.
+func (dp DataPoint) TemperatureInK() float64 {
+	return toKelvin(dp.Temperature, dp.unitSet().temperature)
+}
+
+// ApparentTemperatureInK returns ApparentTemperature in kelvins, converted like TemperatureInK.
+func (dp DataPoint) ApparentTemperatureInK() float64 {
+	return toKelvin(dp.ApparentTemperature, dp.unitSet().temperature)
+}
+
+func toKelvin(v float64, unit UnitKind) float64 {
+	return convertUnit(v, unit, Celsius) + 273.15
+}
+
+// ExpectedPrecip returns PrecipIntensity weighted by PrecipProbability, the
+// probability-weighted precipitation rate in the point's intensity units (in/h for US
+// units, mm/h otherwise).
+func (dp DataPoint) ExpectedPrecip() float64 {
+	return dp.PrecipIntensity * dp.PrecipProbability
+}
+
+// AccumulationByType returns the point's precipitation accumulation split into liquid, snow
+// and ice, in PrecipAccumulation's units. Pirate Weather reports the split directly; for
+// other providers the whole of PrecipAccumulation is attributed to the point's PrecipType,
+// with sleet counted as ice. split is false when the split was inferred this way.
+func (dp DataPoint) AccumulationByType() (liquid, snow, ice float64, split bool) {
+	if dp.LiquidAccumulation != 0 || dp.SnowAccumulation != 0 || dp.IceAccumulation != 0 {
+		return dp.LiquidAccumulation, dp.SnowAccumulation, dp.IceAccumulation, true
+	}
+	switch PrecipType(dp.PrecipType) {
+	case PrecipSnow:
+		return 0, dp.PrecipAccumulation, 0, false
+	case PrecipSleet:
+		return 0, 0, dp.PrecipAccumulation, false
+	}
+	return dp.PrecipAccumulation, 0, 0, false
+}
+
+// FrozenAccumulation returns the snow and ice accumulation of the point, as reported by
+// AccumulationByType.
+func (dp DataPoint) FrozenAccumulation() float64 {
+	_, snow, ice, _ := dp.AccumulationByType()
+	return snow + ice
+}
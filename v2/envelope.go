@@ -0,0 +1,30 @@
+package forecast
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// UnwrapField returns a Client.Unwrap function that extracts the forecast from the named
+// top-level field of an envelope such as {"data": {...}, "meta": {...}}.
+func UnwrapField(field string) func(body []byte) ([]byte, error) {
+	return func(body []byte) ([]byte, error) {
+		var envelope map[string]json.RawMessage
+		err := json.Unmarshal(body, &envelope)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidJSON, err)
+		}
+		inner, ok := envelope[field]
+		if !ok {
+			return nil, fmt.Errorf("forecast: response envelope has no %q field", field)
+		}
+		return inner, nil
+	}
+}
+
+// isEmpty reports whether f lacks every field a successful response carries.
+func (f *Forecast) isEmpty() bool {
+	return f.Latitude == 0 && f.Longitude == 0 && f.Timezone == "" &&
+		f.Currently.Time == 0 && len(f.Minutely.Data) == 0 && len(f.Hourly.Data) == 0 &&
+		len(f.Daily.Data) == 0 && len(f.Alerts) == 0 && f.Flags.Units == ""
+}
@@ -0,0 +1,62 @@
+package forecast
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrInvalidKey is returned when the API rejects the API key.
+var ErrInvalidKey = errors.New("forecast: invalid API key")
+
+// ErrEmptyResponse is returned when the API responds with an empty body.
+var ErrEmptyResponse = errors.New("forecast: empty response body")
+
+// ErrTruncatedResponse is returned, wrapped with details, when the response body ends early,
+// typically because the connection dropped mid-response.
+var ErrTruncatedResponse = errors.New("forecast: truncated response body")
+
+// ErrInvalidJSON is returned, wrapped with the decoding error, when a complete response body
+// is not a valid forecast document.
+var ErrInvalidJSON = errors.New("forecast: invalid JSON in response body")
+
+// ErrEmptyForecast is returned when a response decodes without error but contains none of the
+// fields every forecast carries, which usually means the forecast is wrapped in an envelope
+// and Client.Unwrap needs to be set.
+var ErrEmptyForecast = errors.New("forecast: response contains no forecast data")
+
+// ErrResponseTooLarge is returned, wrapped with the limit, when a response body exceeds the
+// client's MaxResponseSize.
+var ErrResponseTooLarge = errors.New("forecast: response body too large")
+
+// StatusError is returned when the API responds with an unexpected HTTP status.
+type StatusError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("forecast: unexpected response status %s", e.Status)
+}
+
+// checkStatus maps a non-200 response to an error.
+func checkStatus(res *http.Response) error {
+	switch res.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusForbidden, http.StatusUnauthorized:
+		return ErrInvalidKey
+	}
+	return &StatusError{StatusCode: res.StatusCode, Status: res.Status}
+}
+
+// APIError is returned when a response body describes an error, as some proxies send with a
+// 200 status: it has an error code or message and none of the coordinates every forecast carries.
+type APIError struct {
+	Code    int
+	Message string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("forecast: API error %d: %s", e.Code, e.Message)
+}
@@ -0,0 +1,33 @@
+package forecast
+
+// Fetcher fetches the current forecast for a coordinate. Code that depends on a Fetcher
+// rather than on Client or Get can be tested with a StubFetcher instead of the network.
+type Fetcher interface {
+	Forecast(lat string, long string) (*Forecast, error)
+}
+
+// Forecast implements Fetcher. It is Fetch without options: current conditions in the
+// client's Units.
+func (c *Client) Forecast(lat string, long string) (*Forecast, error) {
+	return c.Fetch(lat, long)
+}
+
+// StubFetcher is a Fetcher that returns a canned result for every coordinate.
+type StubFetcher struct {
+	// Result is returned, as a fresh copy, when Err is nil.
+	Result *Forecast
+
+	// Err, when non-nil, is returned instead of Result.
+	Err error
+}
+
+// Forecast implements Fetcher.
+func (s StubFetcher) Forecast(lat string, long string) (*Forecast, error) {
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	if s.Result == nil {
+		return &Forecast{}, nil
+	}
+	return s.Result.Clone(), nil
+}
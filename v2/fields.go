@@ -0,0 +1,64 @@
+package forecast
+
+// Fields flattens the current conditions and today's daily summary into a map for template
+// engines and structured loggers. Values are in the forecast's own units, named by the
+// *_unit keys, and times are time.Time values in the forecast's time zone. The keys are:
+//
+//	latitude, longitude, timezone, units
+//	temperature_unit, wind_unit, distance_unit, pressure_unit
+//	current.time, current.summary, current.icon, current.temperature,
+//	current.apparent_temperature, current.dew_point, current.humidity, current.pressure,
+//	current.wind_speed, current.wind_gust, current.wind_bearing, current.cloud_cover,
+//	current.uv_index, current.visibility, current.precip_intensity,
+//	current.precip_probability, current.precip_type
+//	today.time, today.summary, today.icon, today.high, today.low, today.sunrise,
+//	today.sunset, today.precip_probability, today.precip_type
+//
+// The current.* keys are always present, with absent values left at zero. The today.* keys
+// are present only when the forecast has a daily block; today.high and today.low use the
+// High and Low accessors.
+func (f *Forecast) Fields() map[string]interface{} {
+	loc := f.Location()
+	cur := f.Currently
+	m := map[string]interface{}{
+		"latitude":         f.Latitude,
+		"longitude":        f.Longitude,
+		"timezone":         f.Timezone,
+		"units":            f.ResolvedUnits(),
+		"temperature_unit": f.TempUnit(),
+		"wind_unit":        f.WindUnit(),
+		"distance_unit":    f.DistanceUnit(),
+		"pressure_unit":    f.PressureUnit(),
+
+		"current.time":                 unixTime(cur.Time, loc),
+		"current.summary":              cur.Summary,
+		"current.icon":                 cur.Icon,
+		"current.temperature":          cur.Temperature,
+		"current.apparent_temperature": cur.ApparentTemperature,
+		"current.dew_point":            cur.DewPoint,
+		"current.humidity":             cur.Humidity,
+		"current.pressure":             cur.Pressure,
+		"current.wind_speed":           cur.WindSpeed,
+		"current.wind_gust":            cur.WindGust,
+		"current.wind_bearing":         cur.WindBearing,
+		"current.cloud_cover":          cur.CloudCover,
+		"current.uv_index":             cur.UVIndex,
+		"current.visibility":           cur.Visibility,
+		"current.precip_intensity":     cur.PrecipIntensity,
+		"current.precip_probability":   cur.PrecipProbability,
+		"current.precip_type":          cur.PrecipType,
+	}
+	if len(f.Daily.Data) > 0 {
+		today := f.Daily.Data[0]
+		m["today.time"] = unixTime(today.Time, loc)
+		m["today.summary"] = today.Summary
+		m["today.icon"] = today.Icon
+		m["today.high"] = today.High()
+		m["today.low"] = today.Low()
+		m["today.sunrise"] = unixTime(today.SunriseTime, loc)
+		m["today.sunset"] = unixTime(today.SunsetTime, loc)
+		m["today.precip_probability"] = today.PrecipProbability
+		m["today.precip_type"] = today.PrecipType
+	}
+	return m
+}
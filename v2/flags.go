@@ -0,0 +1,52 @@
+package forecast
+
+// IsDegraded reports whether the API flagged the primary model as unavailable, meaning the
+// forecast was produced from secondary sources and should be presented with less confidence.
+func (f *Forecast) IsDegraded() bool {
+	return f.Flags.DarkSkyUnavailable != ""
+}
+
+// sourceNames maps the source codes reported in Flags.Sources to human-readable names.
+var sourceNames = map[string]string{
+	"cmc":        "Canadian Meteorological Center",
+	"darksky":    "Dark Sky",
+	"datapoint":  "UK Met Office Datapoint",
+	"ecpa":       "Environment and Climate Change Canada Public Alerts",
+	"gfs":        "NOAA Global Forecast System",
+	"hrrr":       "NOAA High-Resolution Rapid Refresh",
+	"icon":       "DWD ICON",
+	"isd":        "NOAA Integrated Surface Database",
+	"lamp":       "NOAA Localized Aviation MOS Program",
+	"madis":      "NOAA Meteorological Assimilation Data Ingest System",
+	"meteoalarm": "EUMETNET MeteoAlarm",
+	"metar":      "METAR",
+	"metno":      "Norwegian Meteorological Institute",
+	"nam":        "NOAA North American Mesoscale Model",
+	"nwspa":      "NWS Public Alerts",
+	"sref":       "NOAA Short-Range Ensemble Forecast",
+}
+
+// SourceName returns the human-readable name of a source code reported in Flags.Sources,
+// such as "NOAA Global Forecast System" for "gfs". ok is false for unknown codes.
+func SourceName(code string) (name string, ok bool) {
+	name, ok = sourceNames[code]
+	return name, ok
+}
+
+// DataSources returns the names of the sources that contributed to the forecast, in the
+// order the API listed them. Codes known to SourceName are replaced by their friendly name;
+// unknown codes are returned unchanged. It returns nil when no sources were reported.
+func (f *Forecast) DataSources() []string {
+	if len(f.Flags.Sources) == 0 {
+		return nil
+	}
+	sources := make([]string, len(f.Flags.Sources))
+	for i, code := range f.Flags.Sources {
+		if name, ok := SourceName(code); ok {
+			sources[i] = name
+		} else {
+			sources[i] = code
+		}
+	}
+	return sources
+}
@@ -2,9 +2,8 @@ package forecast
 
 import (
 	"encoding/json"
-	"io/ioutil"
 	"net/http"
-	"strconv"
+	"time"
 )
 
 // URL example:  "https://api.forecast.io/forecast/APIKEY/LATITUDE,LONGITUDE,TIME?units=ca"
@@ -12,14 +11,19 @@ const (
 	BASEURL = "https://api.forecast.io/forecast"
 )
 
+// Now is the time argument of Get and its variants that requests current conditions rather
+// than a Time Machine forecast. An empty time is treated the same way, as is a zero
+// time.Time passed to WithTime.
+const Now = "now"
+
 type Flags struct {
 	DarkSkyUnavailable string   `json:"darksky-unavailable"`
 	DarkSkyStations    []string `json:"darksky-stations"`
 	DataPointStations  []string `json:"datapoint-stations"`
-	ISDStations        []string `json:"isds-stations"`
+	ISDStations        []string `json:"isd-stations"`
 	LAMPStations       []string `json:"lamp-stations"`
-	METARStations      []string `json:"metars-stations"`
-	METNOLicense       string   `json:"metnol-license"`
+	METARStations      []string `json:"metar-stations"`
+	METNOLicense       string   `json:"metno-license"`
 	Sources            []string `json:"sources"`
 	Units              string   `json:"units"`
 }
@@ -67,6 +71,21 @@ type DataPoint struct {
 	UVIndexTime                 int     `json:"uvIndexTime"`
 	Ozone                       float64 `json:"ozone"`
 	Visibility                  float64 `json:"visibility"`
+
+	// Smoke and the per-type accumulations are reported by Pirate Weather. Smoke is the
+	// near-surface smoke concentration in µg/m³. LiquidAccumulation, SnowAccumulation and
+	// IceAccumulation split PrecipAccumulation by type and use its units.
+	Smoke              float64 `json:"smoke"`
+	LiquidAccumulation float64 `json:"liquidAccumulation"`
+	SnowAccumulation   float64 `json:"snowAccumulation"`
+	IceAccumulation    float64 `json:"iceAccumulation"`
+
+	// LocalTime is Time in the forecast's time zone. It is only set by LocalizeTimes.
+	LocalTime time.Time `json:"-"`
+
+	// units records the unit of each of the point's values. It is filled in from
+	// Flags.Units when a Forecast is decoded.
+	units unitSet
 }
 
 type DataBlock struct {
@@ -75,12 +94,14 @@ type DataBlock struct {
 	Data    []DataPoint `json:"data"`
 }
 
-type alert struct {
-	Title       string  `json:"title"`
-	Description string  `json:"description"`
-	Time        float64 `json:"time"`
-	Expires     float64 `json:"expires"`
-	URI         string  `json:"uri"`
+type Alert struct {
+	Title       string   `json:"title"`
+	Regions     []string `json:"regions"`
+	Severity    Severity `json:"severity"`
+	Description string   `json:"description"`
+	Time        float64  `json:"time"`
+	Expires     float64  `json:"expires"`
+	URI         string   `json:"uri"`
 }
 
 type Forecast struct {
@@ -92,10 +113,28 @@ type Forecast struct {
 	Minutely  DataBlock `json:"minutely"`
 	Hourly    DataBlock `json:"hourly"`
 	Daily     DataBlock `json:"daily"`
-	Alerts    []alert   `json:"alerts"`
+	Alerts    []Alert   `json:"alerts"`
 	Flags     Flags     `json:"flags"`
-	APICalls  int       `json:"apicalls"`
-	Code      int       `json:"code"`
+
+	// APICalls is the number of API calls made with the key today. Client requests take it
+	// from the X-Forecast-API-Calls response header when present and otherwise keep the
+	// value decoded from the body's apicalls field, which some providers send instead.
+	APICalls int `json:"apicalls"`
+
+	Code  int    `json:"code"`
+	Error string `json:"error"`
+
+	// PlaceName is the name of the forecast's location. The API does not provide one; it is
+	// filled in by the client's ReverseGeocoder, and is empty when there is none.
+	PlaceName string `json:"placeName,omitempty"`
+
+	// FromCache reports whether the forecast was served from the client's Cache rather than
+	// decoded from a fresh response. APICalls then holds the count from the most recent
+	// request that reached the API; plain cache hits do not change it, while a revalidation
+	// answered with 304 Not Modified updates it if the response carries the header.
+	FromCache bool `json:"-"`
+
+	units unitSet
 }
 
 type Units string
@@ -108,83 +147,122 @@ const (
 	AUTO Units = "auto"
 )
 
+// Get fetches a forecast using a default Client for key.
 func Get(key string, lat string, long string, time string, units Units) (*Forecast, error) {
-	res, err := GetResponse(key, lat, long, time, units)
+	return NewClient(key).Get(lat, long, time, units)
+}
+
+// GetWithResponse fetches a forecast using a default Client for key and also returns the
+// HTTP response, whose body has already been read and closed.
+func GetWithResponse(key string, lat string, long string, time string, units Units) (*Forecast, *http.Response, error) {
+	return NewClient(key).GetWithResponse(lat, long, time, units)
+}
+
+func FromJSON(jsonBlob []byte) (*Forecast, error) {
+	var f Forecast
+	err := FromJSONInto(jsonBlob, &f)
 	if err != nil {
 		return nil, err
 	}
-	defer res.Body.Close()
 
-	body, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
+	return &f, nil
+}
+
+// FromJSONInto decodes jsonBlob into f, reusing the backing arrays of f's data point and
+// alert slices to avoid reallocating them. It is meant for decoding many responses in a
+// loop; anything still referring to f's previous data will see it overwritten. The reused
+// elements are zeroed first, so fields absent from jsonBlob never carry over from the
+// previous response.
+// Timestamps reported in milliseconds, as some compatible providers do, are recognized by
+// their magnitude and converted to seconds.
+func FromJSONInto(jsonBlob []byte, f *Forecast) error {
+	*f = Forecast{
+		Minutely: DataBlock{Data: reuse(f.Minutely.Data)},
+		Hourly:   DataBlock{Data: reuse(f.Hourly.Data)},
+		Daily:    DataBlock{Data: reuse(f.Daily.Data)},
+		Alerts:   reuse(f.Alerts),
 	}
+	return json.Unmarshal(jsonBlob, f)
+}
 
-	f, err := FromJSON(body)
-	if err != nil {
-		return nil, err
+// UnmarshalJSON implements json.Unmarshaler. Besides decoding the fields, it converts
+// millisecond timestamps to seconds and records the units of every data point, so a
+// forecast decoded with encoding/json directly behaves like one returned by FromJSON.
+func (f *Forecast) UnmarshalJSON(b []byte) error {
+	type plain Forecast
+	if err := json.Unmarshal(b, (*plain)(f)); err != nil {
+		return err
 	}
+	f.normalizeTimes()
+	f.setUnits(f.ResolvedUnits().unitSet())
+	return nil
+}
 
-	calls, _ := strconv.Atoi(res.Header.Get("X-Forecast-API-Calls"))
-	f.APICalls = calls
+// reuse returns s emptied, with every element of its backing array zeroed. encoding/json
+// decodes into existing elements in place, so stale ones would otherwise keep the fields
+// a new response omits.
+func reuse[T any](s []T) []T {
+	clear(s[:cap(s)])
+	return s[:0]
+}
 
-	return f, nil
+// Clone returns a deep copy of f that shares no slices with the original.
+func (f *Forecast) Clone() *Forecast {
+	c := *f
+	c.Minutely = f.Minutely.clone()
+	c.Hourly = f.Hourly.clone()
+	c.Daily = f.Daily.clone()
+	if f.Alerts != nil {
+		c.Alerts = make([]Alert, len(f.Alerts))
+		for i, a := range f.Alerts {
+			a.Regions = cloneStrings(a.Regions)
+			c.Alerts[i] = a
+		}
+	}
+	c.Flags.DarkSkyStations = cloneStrings(f.Flags.DarkSkyStations)
+	c.Flags.DataPointStations = cloneStrings(f.Flags.DataPointStations)
+	c.Flags.ISDStations = cloneStrings(f.Flags.ISDStations)
+	c.Flags.LAMPStations = cloneStrings(f.Flags.LAMPStations)
+	c.Flags.METARStations = cloneStrings(f.Flags.METARStations)
+	c.Flags.Sources = cloneStrings(f.Flags.Sources)
+	return &c
 }
 
-func FromJSON(jsonBlob []byte) (*Forecast, error) {
-	var f Forecast
-	err := json.Unmarshal(jsonBlob, &f)
-	if err != nil {
-		return nil, err
+func (db DataBlock) clone() DataBlock {
+	if db.Data != nil {
+		db.Data = append([]DataPoint(nil), db.Data...)
 	}
+	return db
+}
 
-	return &f, nil
+func cloneStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append([]string(nil), s...)
 }
 
-// DataBlockType is useful if you want to exclude certain pieces of data from the response
+// DataBlockType is useful if you want to exclude certain pieces of data from the response.
+// Each value is the block's name in the API.
 type DataBlockType string
 
 const (
-	Currently DataBlockType = "Currently"
-	Minutely  DataBlockType = "Minutely"
-	Hourly    DataBlockType = "Hourly"
-	Daily     DataBlockType = "Daily"
-	Alerts    DataBlockType = "Alerts"
-	FlagData  DataBlockType = "Flags"
-	AlertData DataBlockType = "Alerts"
+	Currently DataBlockType = "currently"
+	Minutely  DataBlockType = "minutely"
+	Hourly    DataBlockType = "hourly"
+	Daily     DataBlockType = "daily"
+	Alerts    DataBlockType = "alerts"
+	FlagData  DataBlockType = "flags"
 )
 
+// GetResponse issues a forecast request using a default Client for key.
+// The caller is responsible for closing the response body.
 func GetResponse(key string, lat string, long string, time string, units Units) (*http.Response, error) {
-	coord := lat + "," + long
-	//TODO(mattwarren1234 12/7/2015) : potentially add 'blocks' as a query param
-	//exclude=[blocks]:
-	// Exclude some number of data blocks from the API response.
-	//  This is useful for reducing latency and saving cache space.
-	//  [blocks] should be a comma-delimeted list (without spaces) of any of the following:
-	//  currently, minutely, hourly, daily, alerts, flags.
-	//  (Crafting a request with all of the above blocks excluded is exceedingly silly and not recommended.)
-
-	var url string
-	if time == "now" {
-		url = BASEURL + "/" + key + "/" + coord + "?units=" + string(units)
-	} else {
-		url = BASEURL + "/" + key + "/" + coord + "," + time + "?units=" + string(units)
-	}
-
-	// if len(exclude) > 0 {
-	// 	url = url + "&exclude="
-	// 	for i, v := range exclude {
-	// 		if i != 0 {
-	// 			url = url + ","
-	// 		}
-	// 		url = url + v
-	// 	}
-	// }
-
-	res, err := http.Get(url)
-	if err != nil {
-		return res, err
-	}
+	return NewClient(key).GetResponse(lat, long, time, units)
+}
 
-	return res, nil
+// DoRequest sends req and decodes the response using a default Client. The request must
+// already carry the API key or whatever credentials the endpoint expects.
+func DoRequest(req *http.Request) (*Forecast, error) {
+	return NewClient("").DoRequest(req)
 }
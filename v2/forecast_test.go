@@ -0,0 +1,102 @@
+package forecast
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// samplePayload returns a response body with 48 hourly and 8 daily points.
+func samplePayload(tb testing.TB) []byte {
+	f := Forecast{Latitude: 37.8267, Longitude: -122.423, Timezone: "America/Los_Angeles", Offset: -8}
+	f.Flags.Units = string(US)
+	for i := 0; i < 48; i++ {
+		f.Hourly.Data = append(f.Hourly.Data, DataPoint{
+			Time:              float64(1450000000 + 3600*i),
+			Summary:           "Partly Cloudy",
+			Icon:              string(PartlyCloudyDay),
+			Temperature:       50 + float64(i%12),
+			Humidity:          0.6,
+			Pressure:          1012,
+			WindSpeed:         5,
+			PrecipProbability: 0.1,
+			Ozone:             300,
+		})
+	}
+	for i := 0; i < 8; i++ {
+		f.Daily.Data = append(f.Daily.Data, DataPoint{
+			Time:            float64(1450000000 + 86400*i),
+			SunriseTime:     float64(1450000000 + 86400*i + 25000),
+			TemperatureHigh: 60,
+			TemperatureLow:  45,
+		})
+	}
+	b, err := json.Marshal(f)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return b
+}
+
+func TestFromJSONIntoClearsReusedPoints(t *testing.T) {
+	var f Forecast
+	if err := FromJSONInto(samplePayload(t), &f); err != nil {
+		t.Fatal(err)
+	}
+	next := []byte(`{"hourly":{"data":[{"time":1450000000,"temperature":40}]}}`)
+	if err := FromJSONInto(next, &f); err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Hourly.Data) != 1 {
+		t.Fatalf("got %d hourly points, want 1", len(f.Hourly.Data))
+	}
+	if dp := f.Hourly.Data[0]; dp.Ozone != 0 || dp.Summary != "" {
+		t.Errorf("previous response leaked into reused point: %+v", dp)
+	}
+	if stale := f.Hourly.Data[:2][1]; stale.Time != 0 {
+		t.Errorf("reused backing array still holds previous point: %+v", stale)
+	}
+	if len(f.Daily.Data) != 0 {
+		t.Errorf("got %d daily points, want 0", len(f.Daily.Data))
+	}
+}
+
+func TestDataBlockTypesDistinct(t *testing.T) {
+	// Each value must be the key the API uses for the block, so WithExclude works.
+	apiNames := map[DataBlockType]bool{
+		"currently": true, "minutely": true, "hourly": true,
+		"daily": true, "alerts": true, "flags": true,
+	}
+	seen := make(map[DataBlockType]bool)
+	for _, b := range []DataBlockType{Currently, Minutely, Hourly, Daily, Alerts, FlagData} {
+		if seen[b] {
+			t.Errorf("DataBlockType %q is used by more than one constant", b)
+		}
+		seen[b] = true
+		if !apiNames[b] {
+			t.Errorf("DataBlockType %q is not an API block name", b)
+		}
+	}
+}
+
+func BenchmarkFromJSON(b *testing.B) {
+	payload := samplePayload(b)
+	b.SetBytes(int64(len(payload)))
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := FromJSON(payload); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkFromJSONInto(b *testing.B) {
+	payload := samplePayload(b)
+	b.SetBytes(int64(len(payload)))
+	b.ReportAllocs()
+	var f Forecast
+	for b.Loop() {
+		if err := FromJSONInto(payload, &f); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
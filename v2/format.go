@@ -0,0 +1,93 @@
+package forecast
+
+import (
+	"math"
+	"strconv"
+)
+
+// fieldPrecision is the number of decimals FormatField uses for each field, keyed by the
+// field's JSON name. Fields that are not listed use one decimal.
+var fieldPrecision = map[string]int{
+	"temperature":             0,
+	"apparentTemperature":     0,
+	"temperatureHigh":         0,
+	"temperatureLow":          0,
+	"temperatureMax":          0,
+	"temperatureMin":          0,
+	"apparentTemperatureHigh": 0,
+	"apparentTemperatureLow":  0,
+	"apparentTemperatureMax":  0,
+	"apparentTemperatureMin":  0,
+	"dewPoint":                0,
+	"precipIntensity":         1,
+	"precipIntensityMax":      1,
+	"precipAccumulation":      1,
+	"liquidAccumulation":      1,
+	"snowAccumulation":        1,
+	"iceAccumulation":         1,
+	"precipProbability":       2,
+	"humidity":                2,
+	"cloudCover":              2,
+	"pressure":                0,
+	"windSpeed":               0,
+	"windGust":                0,
+	"windBearing":             0,
+	"visibility":              1,
+	"ozone":                   0,
+	"moonPhase":               2,
+}
+
+// Round rounds v to the given number of decimals, halves away from zero.
+func Round(v float64, precision int) float64 {
+	p := math.Pow(10, float64(precision))
+	return math.Round(v*p) / p
+}
+
+// FormatValue formats v with exactly precision decimals.
+func FormatValue(v float64, precision int) string {
+	return strconv.FormatFloat(Round(v, precision), 'f', precision, 64)
+}
+
+// FormatField formats v with the default precision for field, which is keyed by the field's
+// JSON name: whole numbers for temperatures, pressure, wind and ozone, two decimals for
+// probabilities, humidity, cloud cover and moon phase, and one decimal otherwise.
+func FormatField(field string, v float64) string {
+	return FormatFieldWith(nil, field, v)
+}
+
+// FormatFieldWith is like FormatField, but takes the number of decimals for field from prec
+// when it is listed there, to suit the application's display rules. prec may be nil.
+func FormatFieldWith(prec map[string]int, field string, v float64) string {
+	precision, ok := prec[field]
+	if !ok {
+		precision, ok = fieldPrecision[field]
+	}
+	if !ok {
+		precision = 1
+	}
+	return FormatValue(v, precision)
+}
+
+// RoundTemperatures returns a copy of the forecast with every temperature field, including
+// dew points, rounded to the nearest integer.
+func (f *Forecast) RoundTemperatures() *Forecast {
+	c := f.Clone()
+	c.Currently.roundTemperatures()
+	for _, db := range []*DataBlock{&c.Minutely, &c.Hourly, &c.Daily} {
+		for i := range db.Data {
+			db.Data[i].roundTemperatures()
+		}
+	}
+	return c
+}
+
+func (dp *DataPoint) roundTemperatures() {
+	for _, v := range []*float64{
+		&dp.Temperature, &dp.ApparentTemperature, &dp.DewPoint,
+		&dp.TemperatureHigh, &dp.TemperatureLow, &dp.TemperatureMax, &dp.TemperatureMin,
+		&dp.ApparentTemperatureHigh, &dp.ApparentTemperatureLow,
+		&dp.ApparentTemperatureMax, &dp.ApparentTemperatureMin,
+	} {
+		*v = math.Round(*v)
+	}
+}
@@ -0,0 +1,24 @@
+package forecast
+
+import "testing"
+
+func TestFormatFieldWith(t *testing.T) {
+	prec := map[string]int{"temperature": 1}
+	tests := []struct {
+		prec  map[string]int
+		field string
+		v     float64
+		want  string
+	}{
+		{nil, "temperature", 21.46, "21"},
+		{nil, "humidity", 0.456, "0.46"},
+		{nil, "unlisted", 3.14159, "3.1"},
+		{prec, "temperature", 21.46, "21.5"},
+		{prec, "humidity", 0.456, "0.46"},
+	}
+	for _, tt := range tests {
+		if got := FormatFieldWith(tt.prec, tt.field, tt.v); got != tt.want {
+			t.Errorf("FormatFieldWith(%v, %q, %v) = %q, want %q", tt.prec, tt.field, tt.v, got, tt.want)
+		}
+	}
+}
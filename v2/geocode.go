@@ -0,0 +1,62 @@
+package forecast
+
+import (
+	"errors"
+	"strconv"
+)
+
+// ErrNoGeocoder is returned by ForecastByName when the client has no Geocoder.
+var ErrNoGeocoder = errors.New("forecast: no geocoder configured")
+
+// LatLong is a geographic coordinate in decimal degrees.
+type LatLong struct {
+	Latitude  float64
+	Longitude float64
+}
+
+func (ll LatLong) lat() string {
+	return strconv.FormatFloat(ll.Latitude, 'f', -1, 64)
+}
+
+func (ll LatLong) long() string {
+	return strconv.FormatFloat(ll.Longitude, 'f', -1, 64)
+}
+
+// Geocoder resolves a place name such as "Paris, FR" to coordinates.
+// The package ships no implementation; plug in any geocoding service.
+type Geocoder interface {
+	Geocode(name string) (LatLong, error)
+}
+
+// ReverseGeocoder resolves coordinates to a place name for display, such as "Paris, France".
+// The package ships no implementation; plug in any geocoding service.
+type ReverseGeocoder interface {
+	ReverseGeocode(ll LatLong) (string, error)
+}
+
+// setPlaceName fills in f.PlaceName using the client's ReverseGeocoder, if it has one. A
+// failure is logged and leaves PlaceName empty, since the forecast itself is still usable.
+func (c *Client) setPlaceName(f *Forecast) {
+	if c.ReverseGeocoder == nil || f.PlaceName != "" {
+		return
+	}
+	name, err := c.ReverseGeocoder.ReverseGeocode(LatLong{Latitude: f.Latitude, Longitude: f.Longitude})
+	if err != nil {
+		c.logger().Warn("forecast reverse geocoding failed", "latitude", f.Latitude, "longitude", f.Longitude, "error", err)
+		return
+	}
+	f.PlaceName = name
+}
+
+// ForecastByName geocodes name with the client's Geocoder and fetches the current forecast
+// for the result in the client's Units.
+func (c *Client) ForecastByName(name string) (*Forecast, error) {
+	if c.Geocoder == nil {
+		return nil, ErrNoGeocoder
+	}
+	ll, err := c.Geocoder.Geocode(name)
+	if err != nil {
+		return nil, err
+	}
+	return c.Get(ll.lat(), ll.long(), Now, c.Units)
+}
@@ -0,0 +1,52 @@
+package forecast
+
+import (
+	"bytes"
+	"encoding/gob"
+)
+
+// forecastFields has the fields of Forecast without its methods, so that it can be handed to
+// encoding/gob from within GobEncode and GobDecode without recursing.
+type forecastFields Forecast
+
+// gobForecast is the gob representation of a Forecast. Gob skips unexported fields, so the
+// units the values are expressed in, which may differ from Flags.Units after ConvertFields,
+// are carried alongside.
+type gobForecast struct {
+	Forecast *forecastFields
+	Units    [5]UnitKind
+}
+
+// GobEncode implements gob.GobEncoder. Unlike a JSON round trip it preserves every exported
+// field, including LocalTime and FromCache, as well as the units of the values. It has a
+// value receiver so that Forecast values, such as those in a map, can be encoded too.
+func (f Forecast) GobEncode() ([]byte, error) {
+	s := f.unitSet()
+	g := gobForecast{
+		Forecast: (*forecastFields)(&f),
+		Units:    [5]UnitKind{s.temperature, s.speed, s.distance, s.pressure, s.precip},
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(g); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements gob.GobDecoder.
+func (f *Forecast) GobDecode(data []byte) error {
+	var g gobForecast
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&g); err != nil {
+		return err
+	}
+	*f = Forecast{}
+	if g.Forecast != nil {
+		*f = Forecast(*g.Forecast)
+	}
+	s := unitSet{g.Units[0], g.Units[1], g.Units[2], g.Units[3], g.Units[4]}
+	if s == (unitSet{}) {
+		s = f.ResolvedUnits().unitSet()
+	}
+	f.setUnits(s)
+	return nil
+}
@@ -0,0 +1,57 @@
+package forecast
+
+import (
+	"bytes"
+	"encoding/gob"
+	"math"
+	"testing"
+)
+
+func TestGobRoundTrip(t *testing.T) {
+	f, err := FromJSON([]byte(`{"latitude":1,"longitude":2,"flags":{"units":"si"},
+		"currently":{"time":1450000000,"temperature":20,"humidity":0.5,"windSpeed":10},
+		"hourly":{"data":[{"time":1450000000,"temperature":20,"humidity":0.5,"windSpeed":10}]}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.FromCache = true
+	// km/h with every other SI unit matches no unit system, so Flags.Units stays "si".
+	mixed := f.ConvertFields(Fahrenheit, KilometersPerHour, "", "")
+
+	check := func(name string, got *Forecast) {
+		t.Helper()
+		if got.Latitude != 1 || !got.FromCache {
+			t.Errorf("%s: fields not preserved: %+v", name, got)
+		}
+		if got.unitSet() != mixed.unitSet() || got.Hourly.Data[0].unitSet() != mixed.unitSet() {
+			t.Errorf("%s: units %v, want %v", name, got.unitSet(), mixed.unitSet())
+		}
+		// Converting back must start from the preserved units.
+		if v := got.ConvertTo(SI).Currently.WindSpeed; math.Abs(v-10) > 1e-9 {
+			t.Errorf("%s: wind speed back in SI = %v, want 10", name, v)
+		}
+	}
+
+	// A pointer.
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(mixed); err != nil {
+		t.Fatal(err)
+	}
+	var p Forecast
+	if err := gob.NewDecoder(&buf).Decode(&p); err != nil {
+		t.Fatal(err)
+	}
+	check("pointer", &p)
+
+	// A value, inside a map.
+	buf.Reset()
+	if err := gob.NewEncoder(&buf).Encode(map[string]Forecast{"here": *mixed}); err != nil {
+		t.Fatal(err)
+	}
+	var m map[string]Forecast
+	if err := gob.NewDecoder(&buf).Decode(&m); err != nil {
+		t.Fatal(err)
+	}
+	v := m["here"]
+	check("map value", &v)
+}
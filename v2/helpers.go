@@ -0,0 +1,367 @@
+package forecast
+
+import (
+	"math"
+	"time"
+)
+
+// DefaultPressureDeadband is the deadband PressureTrend uses, in hPa (equivalently
+// millibars). Sea-level pressure routinely drifts by fractions of a hPa over a few hours, so
+// smaller changes carry little signal.
+const DefaultPressureDeadband = 1.0
+
+// PressureTrend is PressureTrendWith using DefaultPressureDeadband.
+func (f *Forecast) PressureTrend(hours int) string {
+	return f.PressureTrendWith(hours, DefaultPressureDeadband)
+}
+
+// PressureTrendWith compares the pressure of the first hourly data point with the one hours
+// later (or the last available one) and returns "rising", "falling" or "steady", the latter
+// when the change is smaller than deadband. It returns "" when the hourly block has fewer
+// than two data points or hours < 1. The deadband is in hPa (equivalently millibars)
+// whatever units the forecast is in: pressures are converted to hPa before comparing, so it
+// also applies after ConvertFields to, say, InchesOfMercury.
+func (f *Forecast) PressureTrendWith(hours int, deadband float64) string {
+	data := f.Hourly.Data
+	if len(data) < 2 || hours < 1 {
+		return ""
+	}
+	if hours >= len(data) {
+		hours = len(data) - 1
+	}
+	a, b := data[0], data[hours]
+	change := convertUnit(b.Pressure, b.unitSet().pressure, Hectopascals) -
+		convertUnit(a.Pressure, a.unitSet().pressure, Hectopascals)
+	switch {
+	case change >= deadband:
+		return "rising"
+	case change <= -deadband:
+		return "falling"
+	}
+	return "steady"
+}
+
+// DryStreaks groups consecutive days in the daily block whose precipitation probability is
+// below probThreshold. Streaks are returned in chronological order; the result is empty when
+// every day is wet.
+func (f *Forecast) DryStreaks(probThreshold float64) [][]DataPoint {
+	var streaks [][]DataPoint
+	var current []DataPoint
+	for _, dp := range f.Daily.Data {
+		if dp.PrecipProbability < probThreshold {
+			current = append(current, dp)
+			continue
+		}
+		if len(current) > 0 {
+			streaks = append(streaks, current)
+			current = nil
+		}
+	}
+	if len(current) > 0 {
+		streaks = append(streaks, current)
+	}
+	return streaks
+}
+
+// LongestDryWindow returns the longest uninterrupted stretch between now and now+within in
+// which every hourly point has a precipitation probability below threshold, the hourly
+// counterpart of DryStreaks. Each point is taken to cover the hour starting at its Time, and
+// the window is clipped to [now, now+within). A gap in the hourly data ends a window. Of
+// several equally long windows the earliest is returned. ok is false when no hour in range
+// qualifies.
+func (f *Forecast) LongestDryWindow(within time.Duration, threshold float64, now time.Time) (start, end time.Time, ok bool) {
+	limit := now.Add(within)
+	var runStart, runEnd time.Time
+	for _, dp := range f.Hourly.Sorted().Data {
+		hourStart := time.Unix(int64(dp.Time), 0)
+		hourEnd := hourStart.Add(time.Hour)
+		if !hourEnd.After(now) || !hourStart.Before(limit) {
+			continue
+		}
+		if dp.PrecipProbability >= threshold {
+			runStart = time.Time{}
+			continue
+		}
+		if runStart.IsZero() || !hourStart.Equal(runEnd) {
+			runStart = hourStart
+		}
+		runEnd = hourEnd
+
+		s, e := maxTime(runStart, now), minTime(runEnd, limit)
+		if !ok || e.Sub(s) > end.Sub(start) {
+			start, end, ok = s, e, true
+		}
+	}
+	return start, end, ok
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+// FrostRiskTonight reports whether the next overnight low is at or below freezing
+// (32°F, or 0°C for metric units) and returns that low in the forecast's units.
+// The first daily point whose low has not already passed, judged by the time of the
+// current conditions, is used, so once tonight's low has occurred the following night is
+// reported instead. ok is false when no such point exists.
+func (f *Forecast) FrostRiskTonight() (risk bool, low float64, ok bool) {
+	now := f.Currently.Time
+	for _, dp := range f.Daily.Data {
+		if t := dp.lowTime(); t != 0 && t < now {
+			continue
+		}
+		low = dp.Low()
+		freezing := convertUnit(32, Fahrenheit, dp.unitSet().temperature)
+		return low <= freezing, low, true
+	}
+	return false, 0, false
+}
+
+// WarmestDay returns the daily point with the highest High and its index in the daily block.
+// Ties go to the earliest day. ok is false when the daily block is empty.
+func (f *Forecast) WarmestDay() (dp DataPoint, index int, ok bool) {
+	return f.extremeDay(func(a, b DataPoint) bool { return a.High() > b.High() })
+}
+
+// ColdestDay returns the daily point with the lowest Low and its index in the daily block.
+// Ties go to the earliest day. ok is false when the daily block is empty.
+func (f *Forecast) ColdestDay() (dp DataPoint, index int, ok bool) {
+	return f.extremeDay(func(a, b DataPoint) bool { return a.Low() < b.Low() })
+}
+
+// extremeDay returns the first daily point for which no other point is better.
+func (f *Forecast) extremeDay(better func(a, b DataPoint) bool) (DataPoint, int, bool) {
+	if len(f.Daily.Data) == 0 {
+		return DataPoint{}, -1, false
+	}
+	best := 0
+	for i, dp := range f.Daily.Data {
+		if better(dp, f.Daily.Data[best]) {
+			best = i
+		}
+	}
+	return f.Daily.Data[best], best, true
+}
+
+// HourlyTemperatures returns the temperatures of the first n points of the hourly block, in
+// the forecast's units. Fewer values are returned when fewer hours are available.
+func (f *Forecast) HourlyTemperatures(n int) []float64 {
+	data := f.Hourly.Data
+	if n < len(data) {
+		data = data[:max(n, 0)]
+	}
+	temps := make([]float64, len(data))
+	for i, dp := range data {
+		temps[i] = dp.Temperature
+	}
+	return temps
+}
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders HourlyTemperatures(n) as a line of Unicode block characters scaled
+// between the lowest and highest value. A flat series renders at mid height.
+func (f *Forecast) Sparkline(n int) string {
+	temps := f.HourlyTemperatures(n)
+	if len(temps) == 0 {
+		return ""
+	}
+	lo, hi := temps[0], temps[0]
+	for _, t := range temps {
+		lo, hi = min(lo, t), max(hi, t)
+	}
+	line := make([]rune, len(temps))
+	for i, t := range temps {
+		level := len(sparkBlocks) / 2
+		if hi > lo {
+			level = int((t - lo) / (hi - lo) * float64(len(sparkBlocks)-1))
+		}
+		line[i] = sparkBlocks[level]
+	}
+	return string(line)
+}
+
+// MeanDailyHigh averages High across the daily block, skipping days without a high. A
+// reported high of exactly 0° counts. It returns the mean and the number of days included;
+// both are zero when no day has a high.
+func (f *Forecast) MeanDailyHigh() (mean float64, count int) {
+	return f.meanDaily(DataPoint.High, DataPoint.hasHigh)
+}
+
+// MeanDailyLow averages Low across the daily block, skipping days without a low. A reported
+// low of exactly 0° counts. It returns the mean and the number of days included; both are
+// zero when no day has a low.
+func (f *Forecast) MeanDailyLow() (mean float64, count int) {
+	return f.meanDaily(DataPoint.Low, DataPoint.hasLow)
+}
+
+func (f *Forecast) meanDaily(value func(DataPoint) float64, present func(DataPoint) bool) (float64, int) {
+	var sum float64
+	var count int
+	for _, dp := range f.Daily.Data {
+		if present(dp) {
+			sum += value(dp)
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, 0
+	}
+	return sum / float64(count), count
+}
+
+// DiurnalRange returns the temperature swing of the day at dayIndex in the daily block, High
+// minus Low, in the forecast's units. ok is false when the index is out of range or the day
+// lacks a high or a low.
+func (f *Forecast) DiurnalRange(dayIndex int) (r float64, ok bool) {
+	if dayIndex < 0 || dayIndex >= len(f.Daily.Data) {
+		return 0, false
+	}
+	dp := f.Daily.Data[dayIndex]
+	if !dp.hasHigh() || !dp.hasLow() {
+		return 0, false
+	}
+	return dp.High() - dp.Low(), true
+}
+
+// HighTime returns when the high of the day at dayIndex in the daily block occurs, in the
+// forecast's time zone, taken from TemperatureHighTime or, when the legacy fields are the
+// populated ones, TemperatureMaxTime. ok is false when the index is out of range or the
+// time is absent.
+func (f *Forecast) HighTime(dayIndex int) (t time.Time, ok bool) {
+	return f.dailyTime(dayIndex, DataPoint.highTime)
+}
+
+// LowTime is the Low counterpart of HighTime, using TemperatureLowTime or
+// TemperatureMinTime.
+func (f *Forecast) LowTime(dayIndex int) (t time.Time, ok bool) {
+	return f.dailyTime(dayIndex, DataPoint.lowTime)
+}
+
+func (f *Forecast) dailyTime(dayIndex int, field func(DataPoint) float64) (time.Time, bool) {
+	if dayIndex < 0 || dayIndex >= len(f.Daily.Data) {
+		return time.Time{}, false
+	}
+	sec := field(f.Daily.Data[dayIndex])
+	if sec == 0 {
+		return time.Time{}, false
+	}
+	return unixTime(sec, f.Location()), true
+}
+
+// MeanDiurnalRange averages DiurnalRange across the daily block, skipping days where it is
+// unavailable. It returns the mean and the number of days included; both are zero when no
+// day has both a high and a low.
+func (f *Forecast) MeanDiurnalRange() (mean float64, count int) {
+	var sum float64
+	for i := range f.Daily.Data {
+		if r, ok := f.DiurnalRange(i); ok {
+			sum += r
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, 0
+	}
+	return sum / float64(count), count
+}
+
+// FrontThresholds configures DetectFrontPassage. A front is reported when, within Window
+// hours, the temperature changes by at least Temperature °C, the pressure by at least
+// Pressure hPa and the wind direction by at least WindShift degrees.
+type FrontThresholds struct {
+	Window      int
+	Temperature float64
+	Pressure    float64
+	WindShift   float64
+}
+
+// DefaultFrontThresholds are the thresholds used by DetectFrontPassage.
+var DefaultFrontThresholds = FrontThresholds{Window: 3, Temperature: 4, Pressure: 2, WindShift: 45}
+
+// DetectFrontPassage scans the hourly block for the signature of a front passing, using
+// DefaultFrontThresholds, and returns the time of the hour at which it is first complete.
+func (f *Forecast) DetectFrontPassage() (at time.Time, found bool) {
+	return f.DetectFrontPassageWith(DefaultFrontThresholds)
+}
+
+// DetectFrontPassageWith is DetectFrontPassage with caller-supplied thresholds.
+func (f *Forecast) DetectFrontPassageWith(th FrontThresholds) (at time.Time, found bool) {
+	data := f.Hourly.Data
+	loc := f.Location()
+	for j := range data {
+		for i := max(j-th.Window, 0); i < j; i++ {
+			a, b := data[i], data[j]
+			u := a.unitSet()
+			dt := math.Abs(convertUnit(b.Temperature, u.temperature, Celsius) - convertUnit(a.Temperature, u.temperature, Celsius))
+			dp := math.Abs(convertUnit(b.Pressure, u.pressure, Hectopascals) - convertUnit(a.Pressure, u.pressure, Hectopascals))
+			if dt >= th.Temperature && dp >= th.Pressure && bearingDelta(a.WindBearing, b.WindBearing) >= th.WindShift {
+				return unixTime(b.Time, loc), true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// bearingDelta returns the smallest angle, in degrees, between two compass bearings.
+func bearingDelta(a, b float64) float64 {
+	d := math.Mod(math.Abs(a-b), 360)
+	if d > 180 {
+		d = 360 - d
+	}
+	return d
+}
+
+// GrowingDegreeDays sums the growing degree days of the daily block for a crop whose
+// development starts at base, in the forecast's temperature units: each day contributes
+// the mean of its High and Low minus base, or nothing if the mean is below base. Days
+// lacking a high or a low are skipped.
+func (f *Forecast) GrowingDegreeDays(base float64) float64 {
+	return f.growingDegreeDays(base, math.Inf(1))
+}
+
+// GrowingDegreeDaysCapped is GrowingDegreeDays with the capping used by most crop models,
+// such as base 10°C and cap 30°C for corn: highs above upper count as upper, and highs and
+// lows below base count as base, since development neither accelerates beyond the cap nor
+// reverses below the base.
+func (f *Forecast) GrowingDegreeDaysCapped(base, upper float64) float64 {
+	return f.growingDegreeDays(base, upper)
+}
+
+func (f *Forecast) growingDegreeDays(base, upper float64) float64 {
+	capped := !math.IsInf(upper, 1)
+	var sum float64
+	for _, dp := range f.Daily.Data {
+		if !dp.hasHigh() || !dp.hasLow() {
+			continue
+		}
+		high, low := dp.High(), dp.Low()
+		if capped {
+			high = math.Max(math.Min(high, upper), base)
+			low = math.Max(math.Min(low, upper), base)
+		}
+		sum += math.Max((high+low)/2-base, 0)
+	}
+	return sum
+}
+
+// MaxPrecipProbability returns the highest precipitation probability among the next hours
+// hourly points at or after now, such as for a "rain in the next 24 hours" badge. Fewer
+// points are considered when fewer are available; it returns 0 when there are none.
+func (f *Forecast) MaxPrecipProbability(hours int, now time.Time) float64 {
+	var p float64
+	for _, dp := range f.Hourly.Sorted().Future(now).Limit(hours).Data {
+		p = math.Max(p, dp.PrecipProbability)
+	}
+	return p
+}
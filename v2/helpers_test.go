@@ -0,0 +1,35 @@
+package forecast
+
+import "testing"
+
+func TestPressureTrendConvertedUnits(t *testing.T) {
+	f, err := FromJSON([]byte(`{"latitude":1,"longitude":2,"flags":{"units":"si"},
+		"hourly":{"data":[{"time":1450000000,"pressure":1010},{"time":1450003600,"pressure":1013}]}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := f.PressureTrend(1); got != "rising" {
+		t.Errorf("hPa: got %q, want rising", got)
+	}
+	inHg := f.ConvertFields("", "", "", InchesOfMercury)
+	if got := inHg.PressureTrend(1); got != "rising" {
+		t.Errorf("inHg: got %q, want rising", got)
+	}
+	if got := inHg.PressureTrendWith(1, 5); got != "steady" {
+		t.Errorf("inHg with a 5 hPa deadband: got %q, want steady", got)
+	}
+}
+
+func TestMeanDailyCountsZero(t *testing.T) {
+	f := &Forecast{Daily: DataBlock{Data: []DataPoint{
+		{Time: 1450000000, TemperatureHigh: 4, TemperatureHighTime: 1450040000, TemperatureLow: -4, TemperatureLowTime: 1450010000},
+		{Time: 1450086400, TemperatureHigh: 0, TemperatureHighTime: 1450126400, TemperatureLow: 0, TemperatureLowTime: 1450096400},
+		{Time: 1450172800},
+	}}}
+	if mean, n := f.MeanDailyHigh(); mean != 2 || n != 2 {
+		t.Errorf("MeanDailyHigh() = %v, %d, want 2, 2", mean, n)
+	}
+	if mean, n := f.MeanDailyLow(); mean != -2 || n != 2 {
+		t.Errorf("MeanDailyLow() = %v, %d, want -2, 2", mean, n)
+	}
+}
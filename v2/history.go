@@ -0,0 +1,61 @@
+package forecast
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultHistoryConcurrency is the request limit used by GetHistory when
+// Client.HistoryConcurrency is unset.
+const DefaultHistoryConcurrency = 4
+
+// GetHistory fetches hourly history between from and to using a default Client for key.
+func GetHistory(key string, lat string, long string, from time.Time, to time.Time, units Units) (DataBlock, error) {
+	return NewClient(key).GetHistory(lat, long, from, to, units)
+}
+
+// GetHistory issues one Time Machine request per day between from and to, at most
+// HistoryConcurrency at a time, and merges their hourly data into a single block sorted by
+// time with duplicate hours removed. Whole days are returned, so the block may start before
+// from and end after to.
+// If some requests fail, the hours that were fetched are still returned together with an
+// error describing every failure.
+func (c *Client) GetHistory(lat string, long string, from time.Time, to time.Time, units Units) (DataBlock, error) {
+	var days []time.Time
+	for t := from; !t.After(to); t = t.AddDate(0, 0, 1) {
+		days = append(days, t)
+	}
+	if len(days) > 0 && !sameDate(days[len(days)-1], to) {
+		days = append(days, to)
+	}
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		points []DataPoint
+		errs   []error
+		sem    = make(chan struct{}, c.historyConcurrency())
+	)
+	for _, day := range days {
+		wg.Add(1)
+		go func(day time.Time) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			f, err := c.Get(lat, long, strconv.FormatInt(day.Unix(), 10), units)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, err)
+				return
+			}
+			points = append(points, f.Hourly.Data...)
+		}(day)
+	}
+	wg.Wait()
+
+	return DataBlock{Data: DataBlock{Data: points}.Sorted().dedupe()}, errors.Join(errs...)
+}
@@ -0,0 +1,56 @@
+package forecast
+
+// Icon is a machine-readable summary of the weather, as found in DataPoint.Icon and DataBlock.Icon.
+type Icon string
+
+const (
+	ClearDay          Icon = "clear-day"
+	ClearNight        Icon = "clear-night"
+	Rain              Icon = "rain"
+	Snow              Icon = "snow"
+	Sleet             Icon = "sleet"
+	Wind              Icon = "wind"
+	Fog               Icon = "fog"
+	Cloudy            Icon = "cloudy"
+	PartlyCloudyDay   Icon = "partly-cloudy-day"
+	PartlyCloudyNight Icon = "partly-cloudy-night"
+
+	// The API reserves these for future use.
+	Hail         Icon = "hail"
+	Thunderstorm Icon = "thunderstorm"
+	Tornado      Icon = "tornado"
+)
+
+// iconEmojis maps each icon to an emoji for text-only output.
+var iconEmojis = map[Icon]string{
+	ClearDay:          "☀️",
+	ClearNight:        "🌙",
+	Rain:              "🌧️",
+	Snow:              "❄️",
+	Sleet:             "🌨️",
+	Wind:              "💨",
+	Fog:               "🌫️",
+	Cloudy:            "☁️",
+	PartlyCloudyDay:   "⛅",
+	PartlyCloudyNight: "🌙☁️",
+	Hail:              "🧊",
+	Thunderstorm:      "⛈️",
+	Tornado:           "🌪️",
+}
+
+// DefaultEmoji is returned by Emoji for unknown icons.
+const DefaultEmoji = "🌡️"
+
+// Emoji returns an emoji for the icon for text-only output, or DefaultEmoji if the icon is
+// unknown.
+func (i Icon) Emoji() string {
+	if e, ok := iconEmojis[i]; ok {
+		return e
+	}
+	return DefaultEmoji
+}
+
+// IconEmoji returns the emoji for the point's icon, or DefaultEmoji if the icon is unknown.
+func (dp DataPoint) IconEmoji() string {
+	return Icon(dp.Icon).Emoji()
+}
@@ -0,0 +1,16 @@
+package forecast
+
+import "testing"
+
+func TestIconEmojisDistinct(t *testing.T) {
+	seen := make(map[string]Icon)
+	for icon, e := range iconEmojis {
+		if other, ok := seen[e]; ok {
+			t.Errorf("%s and %s share the emoji %s", icon, other, e)
+		}
+		seen[e] = icon
+	}
+	if got := Icon("unknown").Emoji(); got != DefaultEmoji {
+		t.Errorf("unknown icon: got %s, want %s", got, DefaultEmoji)
+	}
+}
@@ -0,0 +1,53 @@
+package forecast
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// ErrMalformedKey is returned, wrapped with details, when a key does not look like an API key.
+var ErrMalformedKey = errors.New("forecast: malformed API key")
+
+// Bounds on the length of a key read by ClientFromKeyFile.
+const (
+	minKeyFileLen = 8
+	maxKeyFileLen = 128
+)
+
+// ClientFromKeyFile returns a Client using the API key stored in the file at path, as with
+// secrets mounted by Docker or Kubernetes. Surrounding whitespace is trimmed; the key must
+// then pass ValidateKey and be 8 to 128 bytes long, which catches files holding something
+// other than a key, such as a placeholder or a whole configuration file.
+func ClientFromKeyFile(path string) (*Client, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key := strings.TrimSpace(string(b))
+	if err := ValidateKey(key); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(key) < minKeyFileLen || len(key) > maxKeyFileLen {
+		return nil, fmt.Errorf("%s: %w: length %d outside %d-%d", path, ErrMalformedKey, len(key), minKeyFileLen, maxKeyFileLen)
+	}
+	return NewClient(key), nil
+}
+
+// ValidateKey rejects keys that are obviously damaged, typically by copying and pasting or
+// by reading them from a file without trimming: empty keys and keys containing whitespace
+// or control characters such as a trailing newline. Other characters are allowed, since
+// some gateways issue keys outside the API's own alphabet; they are escaped in request URLs.
+func ValidateKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("%w: empty", ErrMalformedKey)
+	}
+	for _, r := range key {
+		if unicode.IsSpace(r) || unicode.IsControl(r) {
+			return fmt.Errorf("%w: unexpected character %q", ErrMalformedKey, r)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package forecast
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestClientFromKeyFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		ok       bool
+	}{
+		{"key", "0123456789abcdef\n", true},
+		{"gateway key", "  abc+def/ghi=\n", true},
+		{"one byte", "x\n", false},
+		{"too long", strings.Repeat("k", 129), false},
+		{"empty", "\n", false},
+		{"inner space", "0123456789 abcdef", false},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), "key")
+		if err := os.WriteFile(path, []byte(tt.contents), 0600); err != nil {
+			t.Fatal(err)
+		}
+		c, err := ClientFromKeyFile(path)
+		if tt.ok {
+			if err != nil || c.Key != strings.TrimSpace(tt.contents) {
+				t.Errorf("%s: got %v, %v", tt.name, c, err)
+			}
+		} else if !errors.Is(err, ErrMalformedKey) {
+			t.Errorf("%s: got error %v, want %v", tt.name, err, ErrMalformedKey)
+		}
+	}
+}
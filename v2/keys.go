@@ -0,0 +1,84 @@
+package forecast
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrKeysExhausted is returned when every key in a KeyPool has hit its limit.
+var ErrKeysExhausted = errors.New("forecast: all API keys exhausted")
+
+// KeyUsage describes how a key in a KeyPool has been used.
+type KeyUsage struct {
+	// Requests is the number of requests sent with the key.
+	Requests int
+	// APICalls is the latest X-Forecast-API-Calls count reported for the key.
+	APICalls int
+	// ExhaustedUntil is when the key will be tried again after it was rejected with
+	// 403 or 429. It is zero while the key is usable.
+	ExhaustedUntil time.Time
+}
+
+// KeyPool rotates requests round-robin across several API keys, skipping keys that the API
+// has rejected. The API's daily quotas reset at midnight UTC, so a rejected key is skipped
+// until then. A KeyPool is safe for concurrent use.
+type KeyPool struct {
+	mu    sync.Mutex
+	keys  []string
+	next  int
+	usage map[string]*KeyUsage
+}
+
+// NewKeyPool returns a KeyPool that rotates across keys in the given order.
+func NewKeyPool(keys ...string) *KeyPool {
+	p := &KeyPool{keys: keys, usage: make(map[string]*KeyUsage)}
+	for _, k := range keys {
+		p.usage[k] = &KeyUsage{}
+	}
+	return p
+}
+
+// Usage returns a snapshot of the usage of every key.
+func (p *KeyPool) Usage() map[string]KeyUsage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	usage := make(map[string]KeyUsage, len(p.usage))
+	for k, u := range p.usage {
+		usage[k] = *u
+	}
+	return usage
+}
+
+// pick returns the next usable key and records a request against it.
+func (p *KeyPool) pick(now time.Time) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for range p.keys {
+		k := p.keys[p.next]
+		p.next = (p.next + 1) % len(p.keys)
+		u := p.usage[k]
+		if !u.ExhaustedUntil.IsZero() && now.Before(u.ExhaustedUntil) {
+			continue
+		}
+		u.ExhaustedUntil = time.Time{}
+		u.Requests++
+		return k, nil
+	}
+	return "", ErrKeysExhausted
+}
+
+// exhaust marks k as unusable until the next midnight UTC.
+func (p *KeyPool) exhaust(k string, now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	y, m, d := now.UTC().Date()
+	p.usage[k].ExhaustedUntil = time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC)
+}
+
+// record stores the API call count reported for k.
+func (p *KeyPool) record(k string, calls int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.usage[k].APICalls = calls
+}
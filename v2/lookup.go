@@ -0,0 +1,110 @@
+package forecast
+
+import (
+	"math"
+	"time"
+)
+
+// blockStep is the spacing between consecutive data points of each block.
+var blockStep = map[DataBlockType]time.Duration{
+	Currently: time.Minute,
+	Minutely:  time.Minute,
+	Hourly:    time.Hour,
+	Daily:     24 * time.Hour,
+}
+
+// points returns the data points of block b, treating Currently as a block of one point.
+func (f *Forecast) points(b DataBlockType) []DataPoint {
+	switch b {
+	case Currently:
+		if f.Currently.Time == 0 {
+			return nil
+		}
+		return []DataPoint{f.Currently}
+	case Minutely:
+		return f.Minutely.Data
+	case Hourly:
+		return f.Hourly.Data
+	case Daily:
+		return f.Daily.Data
+	}
+	return nil
+}
+
+// PointAt returns the data point of the given block whose Time is nearest to t. ok is false
+// when the block is empty, is not a data point block, or no point lies within half the
+// block's spacing (a minute, hour or day) of t, as happens before the first point or after
+// the last one.
+func (f *Forecast) PointAt(t time.Time, block DataBlockType) (dp DataPoint, ok bool) {
+	points := f.points(block)
+	if len(points) == 0 {
+		return DataPoint{}, false
+	}
+	target := float64(t.Unix())
+	half := blockStep[block].Seconds() / 2
+	best, bestDist := -1, math.Inf(1)
+	for i, p := range points {
+		if d := math.Abs(p.Time - target); d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	if bestDist > half {
+		return DataPoint{}, false
+	}
+	return points[best], true
+}
+
+// IconAt returns the icon of the hourly point nearest to t. ok is false when t is outside
+// the hourly block, as defined by PointAt.
+func (f *Forecast) IconAt(t time.Time) (icon string, ok bool) {
+	dp, ok := f.PointAt(t, Hourly)
+	return dp.Icon, ok
+}
+
+// TimeReaching returns when the hourly temperature, in the forecast's units, first reaches
+// temp after now. Temperatures are interpolated linearly between consecutive hours, both to
+// estimate the value at now and to place the crossing within the hour. If the value at now
+// already equals temp, now is returned. The time is in the forecast's time zone. ok is false
+// when the target is not reached before the end of the hourly block, or when now lies after
+// its last point.
+func (f *Forecast) TimeReaching(temp float64, now time.Time) (t time.Time, ok bool) {
+	data := f.Hourly.Sorted().Data
+	start := float64(now.Unix())
+	for i := 0; i+1 < len(data); i++ {
+		a, b := data[i], data[i+1]
+		if b.Time <= start || b.Time == a.Time {
+			continue
+		}
+		at, av := a.Time, a.Temperature
+		if at < start {
+			av += (b.Temperature - a.Temperature) * (start - at) / (b.Time - at)
+			at = start
+		}
+		if av == temp {
+			return unixTime(at, f.Location()), true
+		}
+		if (av < temp) == (b.Temperature < temp) && b.Temperature != temp {
+			continue
+		}
+		sec := at + (temp-av)/(b.Temperature-av)*(b.Time-at)
+		return time.Unix(0, int64(sec*float64(time.Second))).In(f.Location()), true
+	}
+	return time.Time{}, false
+}
+
+// ConditionsOn returns the daily point for the calendar date of date, the date-based
+// counterpart of PointAt. Only date's year, month and day are used, as read in its own
+// location; they are compared with each daily point's date in the forecast's time zone, so
+// a date built with time.Date in any location finds the right local day. ok is false when
+// the date lies outside the daily block.
+func (f *Forecast) ConditionsOn(date time.Time) (dp DataPoint, ok bool) {
+	loc := f.Location()
+	y, m, d := date.Date()
+	for _, p := range f.Daily.Data {
+		py, pm, pd := unixTime(p.Time, loc).Date()
+		if p.Time != 0 && py == y && pm == m && pd == d {
+			return p, true
+		}
+	}
+	return DataPoint{}, false
+}
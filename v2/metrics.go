@@ -0,0 +1,15 @@
+package forecast
+
+import "time"
+
+// Metrics receives measurements of the requests a Client sends. Implementations must be
+// safe for concurrent use. A Prometheus implementation is available by building with the
+// "prometheus" tag; see NewPrometheusMetrics.
+type Metrics interface {
+	// ObserveRequest is called once per HTTP request with its duration and either the
+	// response status code or the transport error.
+	ObserveRequest(duration time.Duration, status int, err error)
+
+	// SetAPICalls is called with the X-Forecast-API-Calls count of every decoded response.
+	SetAPICalls(calls int)
+}
@@ -0,0 +1,66 @@
+//go:build prometheus
+
+package forecast
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PrometheusMetrics implements Metrics with the following collectors:
+//
+//	forecast_requests_total            counter, labeled by HTTP status code ("code")
+//	forecast_request_errors_total      counter of requests that failed without a response
+//	forecast_request_duration_seconds  histogram of request latency
+//	forecast_api_calls                 gauge of the latest X-Forecast-API-Calls value
+type PrometheusMetrics struct {
+	requests *prometheus.CounterVec
+	errors   prometheus.Counter
+	duration prometheus.Histogram
+	apiCalls prometheus.Gauge
+}
+
+// NewPrometheusMetrics creates the collectors and registers them with reg.
+func NewPrometheusMetrics(reg prometheus.Registerer) (*PrometheusMetrics, error) {
+	m := &PrometheusMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "forecast_requests_total",
+			Help: "Forecast API requests by HTTP status code.",
+		}, []string{"code"}),
+		errors: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "forecast_request_errors_total",
+			Help: "Forecast API requests that failed without a response.",
+		}),
+		duration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "forecast_request_duration_seconds",
+			Help:    "Forecast API request latency.",
+			Buckets: prometheus.DefBuckets,
+		}),
+		apiCalls: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "forecast_api_calls",
+			Help: "API calls made today, as last reported by the API.",
+		}),
+	}
+	for _, c := range []prometheus.Collector{m.requests, m.errors, m.duration, m.apiCalls} {
+		err := reg.Register(c)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+func (m *PrometheusMetrics) ObserveRequest(duration time.Duration, status int, err error) {
+	m.duration.Observe(duration.Seconds())
+	if err != nil {
+		m.errors.Inc()
+		return
+	}
+	m.requests.WithLabelValues(strconv.Itoa(status)).Inc()
+}
+
+func (m *PrometheusMetrics) SetAPICalls(calls int) {
+	m.apiCalls.Set(float64(calls))
+}
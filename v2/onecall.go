@@ -0,0 +1,239 @@
+package forecast
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// oneCall mirrors the parts of an OpenWeather One Call API response that map onto Forecast.
+type oneCall struct {
+	Lat            float64         `json:"lat"`
+	Lon            float64         `json:"lon"`
+	Timezone       string          `json:"timezone"`
+	TimezoneOffset float64         `json:"timezone_offset"`
+	Current        oneCallPoint    `json:"current"`
+	Minutely       []oneCallMinute `json:"minutely"`
+	Hourly         []oneCallPoint  `json:"hourly"`
+	Daily          []oneCallDay    `json:"daily"`
+	Alerts         []oneCallAlert  `json:"alerts"`
+}
+
+type oneCallWeather struct {
+	Main        string `json:"main"`
+	Description string `json:"description"`
+	Icon        string `json:"icon"`
+}
+
+type oneCallPrecip struct {
+	OneHour float64 `json:"1h"`
+}
+
+type oneCallPoint struct {
+	Dt         float64          `json:"dt"`
+	Temp       float64          `json:"temp"`
+	FeelsLike  float64          `json:"feels_like"`
+	Pressure   float64          `json:"pressure"`
+	Humidity   float64          `json:"humidity"`
+	DewPoint   float64          `json:"dew_point"`
+	UVI        float64          `json:"uvi"`
+	Clouds     float64          `json:"clouds"`
+	Visibility float64          `json:"visibility"`
+	WindSpeed  float64          `json:"wind_speed"`
+	WindGust   float64          `json:"wind_gust"`
+	WindDeg    float64          `json:"wind_deg"`
+	Pop        float64          `json:"pop"`
+	Rain       oneCallPrecip    `json:"rain"`
+	Snow       oneCallPrecip    `json:"snow"`
+	Weather    []oneCallWeather `json:"weather"`
+}
+
+type oneCallMinute struct {
+	Dt            float64 `json:"dt"`
+	Precipitation float64 `json:"precipitation"`
+}
+
+type oneCallDay struct {
+	Dt        float64 `json:"dt"`
+	Sunrise   float64 `json:"sunrise"`
+	Sunset    float64 `json:"sunset"`
+	MoonPhase float64 `json:"moon_phase"`
+	Summary   string  `json:"summary"`
+	Temp      struct {
+		Min float64 `json:"min"`
+		Max float64 `json:"max"`
+	} `json:"temp"`
+	Pressure  float64          `json:"pressure"`
+	Humidity  float64          `json:"humidity"`
+	DewPoint  float64          `json:"dew_point"`
+	WindSpeed float64          `json:"wind_speed"`
+	WindGust  float64          `json:"wind_gust"`
+	WindDeg   float64          `json:"wind_deg"`
+	Clouds    float64          `json:"clouds"`
+	Pop       float64          `json:"pop"`
+	Rain      float64          `json:"rain"`
+	Snow      float64          `json:"snow"`
+	UVI       float64          `json:"uvi"`
+	Weather   []oneCallWeather `json:"weather"`
+}
+
+type oneCallAlert struct {
+	SenderName  string  `json:"sender_name"`
+	Event       string  `json:"event"`
+	Start       float64 `json:"start"`
+	End         float64 `json:"end"`
+	Description string  `json:"description"`
+}
+
+// FromOneCallJSON adapts an OpenWeather One Call API response into a Forecast so that code
+// written against this package keeps working. The response must have been requested with
+// units=metric; the result is expressed in SI units, with visibility converted to
+// kilometers, percentages to fractions and snowfall to centimeters. Fields without an
+// equivalent are left zero.
+func FromOneCallJSON(body []byte) (*Forecast, error) {
+	var oc oneCall
+	err := json.Unmarshal(body, &oc)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &Forecast{
+		Latitude:  oc.Lat,
+		Longitude: oc.Lon,
+		Timezone:  oc.Timezone,
+		Offset:    oc.TimezoneOffset / 3600,
+		Currently: oc.Current.dataPoint(),
+		Flags:     Flags{Units: string(SI)},
+	}
+	for _, m := range oc.Minutely {
+		dp := DataPoint{Time: m.Dt, PrecipIntensity: m.Precipitation}
+		if m.Precipitation > 0 {
+			dp.PrecipProbability = 1
+		}
+		f.Minutely.Data = append(f.Minutely.Data, dp)
+	}
+	for _, h := range oc.Hourly {
+		f.Hourly.Data = append(f.Hourly.Data, h.dataPoint())
+	}
+	for _, d := range oc.Daily {
+		f.Daily.Data = append(f.Daily.Data, d.dataPoint())
+	}
+	for _, a := range oc.Alerts {
+		f.Alerts = append(f.Alerts, Alert{
+			Title:       a.Event,
+			Description: a.Description,
+			Time:        a.Start,
+			Expires:     a.End,
+		})
+	}
+	f.setUnits(SI.unitSet())
+
+	return f, nil
+}
+
+// dataPoint converts a current or hourly point. The current point's sun times are not kept:
+// Dark Sky reports them only on daily points, which are recognized by their presence.
+func (p oneCallPoint) dataPoint() DataPoint {
+	dp := DataPoint{
+		Time:                p.Dt,
+		Temperature:         p.Temp,
+		ApparentTemperature: p.FeelsLike,
+		Pressure:            p.Pressure,
+		Humidity:            p.Humidity / 100,
+		DewPoint:            p.DewPoint,
+		UVIndex:             int(p.UVI + 0.5),
+		CloudCover:          p.Clouds / 100,
+		Visibility:          p.Visibility / 1000,
+		WindSpeed:           p.WindSpeed,
+		WindGust:            p.WindGust,
+		WindBearing:         p.WindDeg,
+		PrecipProbability:   p.Pop,
+		PrecipIntensity:     p.Rain.OneHour + p.Snow.OneHour,
+		PrecipType:          oneCallPrecipType(p.Rain.OneHour, p.Snow.OneHour),
+	}
+	dp.Summary, dp.Icon = oneCallSummary(p.Weather)
+	return dp
+}
+
+func (d oneCallDay) dataPoint() DataPoint {
+	dp := DataPoint{
+		Time:               d.Dt,
+		SunriseTime:        d.Sunrise,
+		SunsetTime:         d.Sunset,
+		MoonPhase:          d.MoonPhase,
+		Summary:            d.Summary,
+		TemperatureHigh:    d.Temp.Max,
+		TemperatureLow:     d.Temp.Min,
+		TemperatureMax:     d.Temp.Max,
+		TemperatureMin:     d.Temp.Min,
+		Pressure:           d.Pressure,
+		Humidity:           d.Humidity / 100,
+		DewPoint:           d.DewPoint,
+		WindSpeed:          d.WindSpeed,
+		WindGust:           d.WindGust,
+		WindBearing:        d.WindDeg,
+		CloudCover:         d.Clouds / 100,
+		PrecipProbability:  d.Pop,
+		PrecipAccumulation: d.Snow / 10,
+		PrecipType:         oneCallPrecipType(d.Rain, d.Snow),
+		UVIndex:            int(d.UVI + 0.5),
+	}
+	summary, icon := oneCallSummary(d.Weather)
+	if dp.Summary == "" {
+		dp.Summary = summary
+	}
+	dp.Icon = icon
+	return dp
+}
+
+func oneCallPrecipType(rain, snow float64) string {
+	switch {
+	case snow > rain:
+		return "snow"
+	case rain > 0:
+		return "rain"
+	}
+	return ""
+}
+
+// oneCallIcons maps the leading digits of OpenWeather icon codes to icons.
+var oneCallIcons = map[string]Icon{
+	"03": Cloudy,
+	"04": Cloudy,
+	"09": Rain,
+	"10": Rain,
+	"11": Thunderstorm,
+	"13": Snow,
+	"50": Fog,
+}
+
+// oneCallSummary derives a summary and icon from the first OpenWeather condition.
+// Icon codes end in "d" or "n" for day and night.
+func oneCallSummary(weather []oneCallWeather) (summary string, icon string) {
+	if len(weather) == 0 {
+		return "", ""
+	}
+	w := weather[0]
+	summary = w.Description
+	if summary != "" {
+		summary = strings.ToUpper(summary[:1]) + summary[1:]
+	}
+	if len(w.Icon) != 3 {
+		return summary, ""
+	}
+	night := w.Icon[2] == 'n'
+	switch code := w.Icon[:2]; code {
+	case "01":
+		icon = string(ClearDay)
+		if night {
+			icon = string(ClearNight)
+		}
+	case "02":
+		icon = string(PartlyCloudyDay)
+		if night {
+			icon = string(PartlyCloudyNight)
+		}
+	default:
+		icon = string(oneCallIcons[code])
+	}
+	return summary, icon
+}
@@ -0,0 +1,75 @@
+package forecast
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+// ErrConflictingTime is returned by Client.Fetch when its options request both current
+// conditions and a Time Machine time, or more than one Time Machine time.
+var ErrConflictingTime = errors.New("forecast: conflicting request times")
+
+// RequestOption configures a single Client.Fetch request.
+type RequestOption func(*query) error
+
+// WithTime requests the forecast for t through the Time Machine API instead of current
+// conditions. A zero t is equivalent to WithCurrent. It cannot be combined with WithCurrent
+// or another WithTime.
+func WithTime(t time.Time) RequestOption {
+	if t.IsZero() {
+		return WithCurrent()
+	}
+	return func(q *query) error {
+		if q.current || q.timeSet {
+			return ErrConflictingTime
+		}
+		q.time = strconv.FormatInt(t.Unix(), 10)
+		q.timeSet = true
+		return nil
+	}
+}
+
+// WithCurrent explicitly requests current conditions, which is also the default.
+// It cannot be combined with WithTime.
+func WithCurrent() RequestOption {
+	return func(q *query) error {
+		if q.timeSet {
+			return ErrConflictingTime
+		}
+		q.current = true
+		return nil
+	}
+}
+
+// WithUnits requests values in the unit system u instead of the client's Units.
+func WithUnits(u Units) RequestOption {
+	return func(q *query) error {
+		q.units = u
+		return nil
+	}
+}
+
+// WithLang requests summaries in the given language, such as "fr" or "zh-tw".
+func WithLang(lang string) RequestOption {
+	return func(q *query) error {
+		q.lang = lang
+		return nil
+	}
+}
+
+// WithExclude leaves the given blocks out of the response. It may be given several times.
+func WithExclude(blocks ...DataBlockType) RequestOption {
+	return func(q *query) error {
+		q.exclude = append(q.exclude, blocks...)
+		return nil
+	}
+}
+
+// WithExtendHourly requests hourly data for the next 168 hours instead of 48.
+func WithExtendHourly() RequestOption {
+	return func(q *query) error {
+		q.extend = true
+		return nil
+	}
+}
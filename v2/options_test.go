@@ -0,0 +1,52 @@
+package forecast
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTimeOptionConflicts(t *testing.T) {
+	t1 := time.Unix(1450000000, 0)
+	t2 := time.Unix(1450086400, 0)
+	tests := []struct {
+		name string
+		opts []RequestOption
+		err  error
+		time string
+	}{
+		{"time", []RequestOption{WithTime(t1)}, nil, "1450000000"},
+		{"current", []RequestOption{WithCurrent()}, nil, Now},
+		{"current twice", []RequestOption{WithCurrent(), WithCurrent()}, nil, Now},
+		{"zero time is current", []RequestOption{WithTime(time.Time{}), WithCurrent()}, nil, Now},
+		{"time then current", []RequestOption{WithTime(t1), WithCurrent()}, ErrConflictingTime, ""},
+		{"current then time", []RequestOption{WithCurrent(), WithTime(t1)}, ErrConflictingTime, ""},
+		{"two times", []RequestOption{WithTime(t1), WithTime(t2)}, ErrConflictingTime, ""},
+		{"same time twice", []RequestOption{WithTime(t1), WithTime(t1)}, ErrConflictingTime, ""},
+	}
+	for _, tt := range tests {
+		q := query{time: Now}
+		var err error
+		for _, opt := range tt.opts {
+			if err = opt(&q); err != nil {
+				break
+			}
+		}
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s: got error %v, want %v", tt.name, err, tt.err)
+			continue
+		}
+		if err == nil && q.time != tt.time {
+			t.Errorf("%s: got time %q, want %q", tt.name, q.time, tt.time)
+		}
+	}
+}
+
+func TestFetchRejectsConflictingTimes(t *testing.T) {
+	c := NewClient("key")
+	c.BaseURL = "http://127.0.0.1:0"
+	_, err := c.Fetch("1", "2", WithCurrent(), WithTime(time.Unix(1450000000, 0)))
+	if !errors.Is(err, ErrConflictingTime) {
+		t.Errorf("got error %v, want %v", err, ErrConflictingTime)
+	}
+}
@@ -0,0 +1,41 @@
+package forecast
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+)
+
+// ErrMixedUnits is returned by Save for a forecast whose values, after ConvertFields, are in
+// a mix of units that no unit system matches, since Flags.Units could not describe them.
+var ErrMixedUnits = errors.New("forecast: values are in no single unit system")
+
+// Save writes the forecast to path as JSON in the API's own format, creating or truncating
+// the file, so that it can be read back with Load or served as a test fixture. Flags.Units
+// is written as the unit system the values are actually in, so Load interprets them
+// correctly after ConvertTo or ConvertFields. LocalTime and FromCache are not saved.
+func (f *Forecast) Save(path string) error {
+	u, ok := f.unitSet().system()
+	if !ok {
+		return ErrMixedUnits
+	}
+	if f.ResolvedUnits() != u {
+		c := *f
+		c.Flags.Units = string(u)
+		f = &c
+	}
+	b, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0644)
+}
+
+// Load reads a forecast saved by Save, or any API response body stored in a file.
+func Load(path string) (*Forecast, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return FromJSON(b)
+}
@@ -0,0 +1,41 @@
+package forecast
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveRecordsEffectiveUnits(t *testing.T) {
+	f, err := FromJSON([]byte(`{"latitude":1,"longitude":2,"currently":{"time":1450000000,"temperature":50,"humidity":0.5,"windSpeed":10},"flags":{"units":"si"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "forecast.json")
+
+	// Converting SI wind speeds to km/h puts the values in CA units. The flag is reset to
+	// check that Save does not rely on it.
+	ca := f.ConvertFields("", KilometersPerHour, "", "")
+	ca.Flags.Units = string(SI)
+	if err := ca.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.ResolvedUnits() != CA {
+		t.Errorf("saved units %q, want %q", loaded.Flags.Units, CA)
+	}
+	if loaded.Currently.WindSpeed != 36 {
+		t.Errorf("loaded wind speed %v, want 36", loaded.Currently.WindSpeed)
+	}
+	if ca.Flags.Units != string(SI) {
+		t.Errorf("Save modified the forecast's units to %q", ca.Flags.Units)
+	}
+
+	mixed := f.ConvertFields(Fahrenheit, "", "", "")
+	if err := mixed.Save(path); !errors.Is(err, ErrMixedUnits) {
+		t.Errorf("saving mixed units: got error %v, want %v", err, ErrMixedUnits)
+	}
+}
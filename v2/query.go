@@ -0,0 +1,79 @@
+package forecast
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// query holds the parameters of a single forecast request. Every request URL is built from
+// one by url, so parameters cannot be combined inconsistently.
+type query struct {
+	lat     string
+	long    string
+	time    string
+	units   Units
+	lang    string
+	exclude []DataBlockType
+	extend  bool
+
+	// current and timeSet record which time options were applied, to detect conflicts.
+	current bool
+	timeSet bool
+}
+
+// values returns the query string parameters. url.Values.Encode sorts them by key, and the
+// excluded blocks are sorted and deduplicated, so equal queries always produce identical URLs.
+func (q query) values() url.Values {
+	v := url.Values{}
+	if q.units != "" {
+		v.Set("units", string(q.units))
+	}
+	if q.lang != "" {
+		v.Set("lang", q.lang)
+	}
+	if len(q.exclude) > 0 {
+		blocks := make([]string, 0, len(q.exclude))
+		seen := make(map[string]bool)
+		for _, b := range q.exclude {
+			name := string(b)
+			if !seen[name] {
+				seen[name] = true
+				blocks = append(blocks, name)
+			}
+		}
+		sort.Strings(blocks)
+		v.Set("exclude", strings.Join(blocks, ","))
+	}
+	if q.extend {
+		v.Set("extend", "hourly")
+	}
+	return v
+}
+
+// url returns the canonical request URL for q against base. The key is escaped like the
+// other path segments, so keys containing characters such as '/' or '?' stay intact.
+func (q query) url(base string, key string) string {
+	coord := escapeSegment(q.lat) + "," + escapeSegment(q.long)
+	if !isCurrent(q.time) {
+		coord += "," + escapeSegment(q.time)
+	}
+	u := base + "/" + escapeSegment(key) + "/" + coord
+	if params := q.values().Encode(); params != "" {
+		u += "?" + params
+	}
+	return u
+}
+
+// isCurrent reports whether the request time t asks for current conditions, meaning it is
+// Now or empty.
+func isCurrent(t string) bool {
+	return t == Now || t == ""
+}
+
+// escapeSegment escapes s for use inside the comma-separated coordinate path segment.
+// url.PathEscape leaves '+' alone, but servers commonly decode it as a space, which
+// would corrupt ISO 8601 times such as "2015-12-07T12:00:00+0100".
+func escapeSegment(s string) string {
+	return strings.Replace(url.PathEscape(s), "+", "%2B", -1)
+}
@@ -0,0 +1,80 @@
+package forecast
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQueryURLEscapesTimeOffset(t *testing.T) {
+	q := query{lat: "37.8267", long: "-122.423", time: "2015-12-07T12:00:00+0100"}
+	got := q.url("https://api.darksky.net/forecast", "key")
+	want := "https://api.darksky.net/forecast/key/37.8267,-122.423,2015-12-07T12:00:00%2B0100"
+	if got != want {
+		t.Errorf("url() = %q, want %q", got, want)
+	}
+}
+
+func TestQueryURLParameters(t *testing.T) {
+	tests := []struct {
+		name string
+		q    query
+		want string
+	}{
+		{"none", query{}, "/key/1,2"},
+		{"units", query{units: SI}, "/key/1,2?units=si"},
+		{"lang", query{lang: "zh-tw"}, "/key/1,2?lang=zh-tw"},
+		{"extend", query{extend: true}, "/key/1,2?extend=hourly"},
+		{"exclude", query{exclude: []DataBlockType{Minutely}}, "/key/1,2?exclude=minutely"},
+		{
+			"exclude sorted and deduplicated",
+			query{exclude: []DataBlockType{Hourly, Alerts, Hourly, Minutely}},
+			"/key/1,2?exclude=alerts%2Chourly%2Cminutely",
+		},
+		{
+			"all, sorted by key",
+			query{units: CA, lang: "fr", exclude: []DataBlockType{FlagData}, extend: true},
+			"/key/1,2?exclude=flags&extend=hourly&lang=fr&units=ca",
+		},
+		{"time", query{time: "1450000000", units: UK}, "/key/1,2,1450000000?units=uk"},
+	}
+	for _, tt := range tests {
+		tt.q.lat, tt.q.long = "1", "2"
+		if got := tt.q.url("", "key"); got != tt.want {
+			t.Errorf("%s: url() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestQueryURLCurrentTime(t *testing.T) {
+	tests := []struct {
+		name string
+		time string
+		opts []RequestOption
+	}{
+		{"now", Now, nil},
+		{"empty", "", nil},
+		{"zero time.Time", Now, []RequestOption{WithTime(time.Time{})}},
+	}
+	for _, tt := range tests {
+		q := query{lat: "1", long: "2", time: tt.time}
+		for _, opt := range tt.opts {
+			if err := opt(&q); err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+		}
+		if got, want := q.url("", "key"), "/key/1,2"; got != want {
+			t.Errorf("%s: url() = %q, want %q", tt.name, got, want)
+		}
+	}
+}
+
+func TestQueryURLEscapesKey(t *testing.T) {
+	key := "a+b/c?d"
+	got := query{lat: "1", long: "2"}.url("", key)
+	if want := "/a%2Bb%2Fc%3Fd/1,2"; got != want {
+		t.Errorf("url() = %q, want %q", got, want)
+	}
+	if redacted := redactKeys(got, []string{key}); redacted != "/REDACTED/1,2" {
+		t.Errorf("redactKeys() = %q, want the escaped key redacted", redacted)
+	}
+}
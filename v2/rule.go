@@ -0,0 +1,45 @@
+package forecast
+
+// Rule describes conditions for Matches to test the current conditions against, for
+// declarative automation triggers. Nil and empty fields are ignored, so the zero Rule
+// matches every forecast. Temperatures are in the forecast's units.
+type Rule struct {
+	// MinTemperature and MaxTemperature bound Temperature, inclusively.
+	MinTemperature *float64
+	MaxTemperature *float64
+
+	// MaxPrecipProbability requires PrecipProbability to be strictly below it.
+	MaxPrecipProbability *float64
+
+	// MinUVIndex requires UVIndex to be at least it.
+	MinUVIndex *int
+
+	// Icons requires Icon to be one of them.
+	Icons []Icon
+}
+
+// Matches reports whether the current conditions satisfy every condition set in rule.
+func (f *Forecast) Matches(rule Rule) bool {
+	dp := f.Currently
+	if rule.MinTemperature != nil && dp.Temperature < *rule.MinTemperature {
+		return false
+	}
+	if rule.MaxTemperature != nil && dp.Temperature > *rule.MaxTemperature {
+		return false
+	}
+	if rule.MaxPrecipProbability != nil && dp.PrecipProbability >= *rule.MaxPrecipProbability {
+		return false
+	}
+	if rule.MinUVIndex != nil && dp.UVIndex < *rule.MinUVIndex {
+		return false
+	}
+	if len(rule.Icons) > 0 {
+		for _, icon := range rule.Icons {
+			if Icon(dp.Icon) == icon {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,100 @@
+package forecast
+
+// maxKelvin is the highest temperature, about 70°C or 158°F, that Sanitized accepts as
+// physically plausible near the surface. The hottest reliably recorded air temperature is
+// 56.7°C.
+const maxKelvin = 343.15
+
+// maxPressureHPa is the highest sea-level pressure Sanitized accepts. The record is about
+// 1084 hPa.
+const maxPressureHPa = 1100
+
+// Sanitized returns a copy of the forecast in which values outside their physical range,
+// such as the -999 sentinels some providers use for missing data, are set to zero so that
+// they read as absent and do not skew averages. It also returns how many values were
+// zeroed. The ranges, applied in each point's own units, are:
+//
+//   - temperatures and dew points: from absolute zero to 70°C (158°F)
+//   - humidity, cloud cover, precipitation probability and moon phase: 0 to 1
+//   - pressure: 0 to 1100 hPa
+//   - wind bearing: 0 to 360 degrees
+//   - wind speed, gust, visibility, precipitation, ozone, smoke and UV index: not negative
+//
+// When a temperature with a companion time, such as TemperatureHigh, is zeroed, its time
+// is zeroed too, so that High and the other accessors treat it as absent.
+func (f *Forecast) Sanitized() (*Forecast, int) {
+	c := f.Clone()
+	return c, c.sanitize()
+}
+
+// sanitize zeroes out-of-range values in place and returns how many it zeroed.
+func (f *Forecast) sanitize() int {
+	n := f.Currently.sanitize()
+	for _, db := range []*DataBlock{&f.Minutely, &f.Hourly, &f.Daily} {
+		for i := range db.Data {
+			n += db.Data[i].sanitize()
+		}
+	}
+	return n
+}
+
+func (dp *DataPoint) sanitize() int {
+	s := dp.unitSet()
+	n := 0
+	check := func(v *float64, ok bool, companions ...*float64) {
+		if ok {
+			return
+		}
+		*v = 0
+		for _, t := range companions {
+			*t = 0
+		}
+		n++
+	}
+	temperature := func(v *float64, companions ...*float64) {
+		k := toKelvin(*v, s.temperature)
+		check(v, *v == 0 || (k >= 0 && k <= maxKelvin), companions...)
+	}
+	within := func(v *float64, lo, hi float64) {
+		check(v, *v >= lo && *v <= hi)
+	}
+
+	temperature(&dp.Temperature)
+	temperature(&dp.ApparentTemperature)
+	temperature(&dp.DewPoint)
+	temperature(&dp.TemperatureHigh, &dp.TemperatureHighTime)
+	temperature(&dp.TemperatureLow, &dp.TemperatureLowTime)
+	temperature(&dp.ApparentTemperatureHigh, &dp.ApparentTemperatureHighTime)
+	temperature(&dp.ApparentTemperatureLow, &dp.ApparentTemperatureLowTime)
+	temperature(&dp.TemperatureMax, &dp.TemperatureMaxTime)
+	temperature(&dp.TemperatureMin, &dp.TemperatureMinTime)
+	temperature(&dp.ApparentTemperatureMax, &dp.ApparentTemperatureMaxTime)
+	temperature(&dp.ApparentTemperatureMin, &dp.ApparentTemperatureMinTime)
+
+	within(&dp.Humidity, 0, 1)
+	within(&dp.CloudCover, 0, 1)
+	within(&dp.PrecipProbability, 0, 1)
+	within(&dp.MoonPhase, 0, 1)
+	within(&dp.WindBearing, 0, 360)
+	check(&dp.Pressure, dp.Pressure >= 0 && convertUnit(dp.Pressure, s.pressure, Hectopascals) <= maxPressureHPa)
+	for _, v := range []*float64{
+		&dp.WindSpeed,
+		&dp.WindGust,
+		&dp.Visibility,
+		&dp.PrecipIntensity,
+		&dp.PrecipIntensityMax,
+		&dp.PrecipAccumulation,
+		&dp.LiquidAccumulation,
+		&dp.SnowAccumulation,
+		&dp.IceAccumulation,
+		&dp.Smoke,
+		&dp.Ozone,
+	} {
+		check(v, *v >= 0)
+	}
+	if dp.UVIndex < 0 {
+		dp.UVIndex = 0
+		n++
+	}
+	return n
+}
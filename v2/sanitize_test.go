@@ -0,0 +1,49 @@
+package forecast
+
+import "testing"
+
+func TestSanitizedSentinels(t *testing.T) {
+	f, err := FromJSON([]byte(`{
+		"latitude": 1, "longitude": 2, "flags": {"units": "us"},
+		"currently": {"time": 1450000000, "temperature": -999, "humidity": 0.5, "pressure": -999, "windSpeed": 5},
+		"daily": {"data": [{"time": 1449964800, "sunriseTime": 1449990000,
+			"temperatureHigh": -999, "temperatureHighTime": 1450010000, "temperatureLow": 40, "temperatureLowTime": 1449980000}]}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, n := f.Sanitized()
+	if n != 3 {
+		t.Errorf("zeroed %d values, want 3", n)
+	}
+	if c.Currently.Temperature != 0 || c.Currently.Pressure != 0 {
+		t.Errorf("sentinels kept in current conditions: %+v", c.Currently)
+	}
+	if c.Currently.WindSpeed != 5 || c.Currently.Humidity != 0.5 {
+		t.Errorf("valid values changed in current conditions: %+v", c.Currently)
+	}
+	day := c.Daily.Data[0]
+	if day.TemperatureHigh != 0 || day.TemperatureHighTime != 0 {
+		t.Errorf("sentinel high kept: %v at %v", day.TemperatureHigh, day.TemperatureHighTime)
+	}
+	if day.TemperatureLow != 40 || day.TemperatureLowTime == 0 {
+		t.Errorf("valid low changed: %v at %v", day.TemperatureLow, day.TemperatureLowTime)
+	}
+	if f.Currently.Temperature != -999 {
+		t.Error("Sanitized modified the original forecast")
+	}
+}
+
+func TestClientSanitize(t *testing.T) {
+	const body = `{"latitude":1,"longitude":2,"currently":{"time":1450000000,"temperature":-999,"humidity":0.5}}`
+	c := serve(t, nil, body)
+	c.Sanitize = true
+	f, err := c.Get("1", "2", Now, US)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Currently.Temperature != 0 {
+		t.Errorf("temperature = %v, want the sentinel zeroed", f.Currently.Temperature)
+	}
+}
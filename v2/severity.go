@@ -0,0 +1,75 @@
+package forecast
+
+import "math"
+
+// SeverityWeights sets how much each component contributes to SeverityScoreWith. Each
+// component is first scaled to between 0 and 1:
+//
+//   - Alert: the most severe active alert, 1/3 for an advisory, 2/3 for a watch and 1 for a
+//     warning.
+//   - Precip: current precipitation intensity, reaching 1 at 10 mm/h (0.4 in/h).
+//   - Wind: the stronger of the current sustained wind and gust, reaching 1 at 25 m/s
+//     (56 mph).
+//   - Temperature: how far the current apparent temperature lies into the dangerous range,
+//     rising from 0 at a heat index of 90°F (32°C) to 1 at 115°F (46°C), and from 0 at a
+//     wind chill of 14°F (-10°C) to 1 at -20°F (-29°C).
+//
+// The weights are relative: the score is their weighted mean, scaled to 0–100.
+type SeverityWeights struct {
+	Alert       float64
+	Precip      float64
+	Wind        float64
+	Temperature float64
+}
+
+// DefaultSeverityWeights are the weights used by SeverityScore.
+var DefaultSeverityWeights = SeverityWeights{Alert: 40, Precip: 20, Wind: 20, Temperature: 20}
+
+// SeverityScore rates how much attention the forecast's location needs, from 0 (benign) to
+// 100, using DefaultSeverityWeights. It is meant for ranking several locations against each
+// other rather than as an absolute measure.
+func (f *Forecast) SeverityScore() float64 {
+	return f.SeverityScoreWith(DefaultSeverityWeights)
+}
+
+// SeverityScoreWith is SeverityScore with caller-supplied weights. It uses the current
+// conditions; alerts count as active unless they expired before Currently.Time. It returns
+// 0 when every weight is zero.
+func (f *Forecast) SeverityScoreWith(w SeverityWeights) float64 {
+	total := w.Alert + w.Precip + w.Wind + w.Temperature
+	if total <= 0 {
+		return 0
+	}
+
+	rank := 0
+	for _, a := range f.Alerts {
+		if a.Expires != 0 && a.Expires < f.Currently.Time {
+			continue
+		}
+		rank = max(rank, a.Severity.rank())
+	}
+
+	dp := f.Currently
+	u := dp.unitSet()
+	mmPerHour := convertUnit(dp.PrecipIntensity, u.precip, Millimeters)
+	wind := convertUnit(math.Max(dp.WindSpeed, dp.WindGust), u.speed, MetersPerSecond)
+	feels := u.toFahrenheit(dp.FeelsLike())
+	var temp float64
+	switch {
+	case feels > 90:
+		temp = (feels - 90) / (115 - 90)
+	case feels < 14:
+		temp = (14 - feels) / (14 + 20)
+	}
+
+	score := w.Alert*float64(rank)/3 +
+		w.Precip*clamp01(mmPerHour/10) +
+		w.Wind*clamp01(wind/25) +
+		w.Temperature*clamp01(temp)
+	return 100 * score / total
+}
+
+// clamp01 limits v to the range [0, 1].
+func clamp01(v float64) float64 {
+	return math.Min(math.Max(v, 0), 1)
+}
@@ -0,0 +1,177 @@
+package forecast
+
+import "time"
+
+// Snapshot is a flat, fully typed copy of a Forecast intended for mapping onto other
+// serialization schemas. Timestamps are converted to time.Time in the forecast's time zone,
+// with absent timestamps left as the zero time.Time.
+type Snapshot struct {
+	Latitude  float64
+	Longitude float64
+	Timezone  string
+	Units     Units
+	Degraded  bool
+	Currently SnapshotPoint
+	Minutely  []SnapshotPoint
+	Hourly    []SnapshotPoint
+	Daily     []SnapshotPoint
+	Alerts    []SnapshotAlert
+	Sources   []string
+	APICalls  int
+}
+
+// SnapshotPoint is the Snapshot form of a DataPoint.
+type SnapshotPoint struct {
+	Time                        time.Time
+	Summary                     string
+	Icon                        Icon
+	SunriseTime                 time.Time
+	SunsetTime                  time.Time
+	MoonPhase                   float64
+	PrecipIntensity             float64
+	PrecipIntensityMax          float64
+	PrecipIntensityMaxTime      time.Time
+	PrecipProbability           float64
+	PrecipType                  PrecipType
+	PrecipAccumulation          float64
+	Temperature                 float64
+	ApparentTemperature         float64
+	TemperatureHigh             float64
+	TemperatureHighTime         time.Time
+	TemperatureLow              float64
+	TemperatureLowTime          time.Time
+	ApparentTemperatureHigh     float64
+	ApparentTemperatureHighTime time.Time
+	ApparentTemperatureLow      float64
+	ApparentTemperatureLowTime  time.Time
+	DewPoint                    float64
+	Humidity                    float64
+	Pressure                    float64
+	WindSpeed                   float64
+	WindGust                    float64
+	WindGustTime                time.Time
+	WindBearing                 float64
+	CloudCover                  float64
+	UVIndex                     int
+	UVIndexTime                 time.Time
+	Ozone                       float64
+	Visibility                  float64
+	Smoke                       float64
+	LiquidAccumulation          float64
+	SnowAccumulation            float64
+	IceAccumulation             float64
+}
+
+// SnapshotAlert is the Snapshot form of an Alert.
+type SnapshotAlert struct {
+	Title       string
+	Regions     []string
+	Severity    Severity
+	Description string
+	Time        time.Time
+	Expires     time.Time
+	URI         string
+}
+
+// Snapshot converts f into its flat, typed form.
+func (f *Forecast) Snapshot() Snapshot {
+	loc := f.Location()
+	s := Snapshot{
+		Latitude:  f.Latitude,
+		Longitude: f.Longitude,
+		Timezone:  f.Timezone,
+		Units:     f.ResolvedUnits(),
+		Degraded:  f.IsDegraded(),
+		Currently: f.Currently.snapshot(loc),
+		Minutely:  f.Minutely.snapshot(loc),
+		Hourly:    f.Hourly.snapshot(loc),
+		Daily:     f.Daily.snapshot(loc),
+		Sources:   append([]string(nil), f.Flags.Sources...),
+		APICalls:  f.APICalls,
+	}
+	for _, a := range f.Alerts {
+		s.Alerts = append(s.Alerts, SnapshotAlert{
+			Title:       a.Title,
+			Regions:     append([]string(nil), a.Regions...),
+			Severity:    a.Severity,
+			Description: a.Description,
+			Time:        unixTime(a.Time, loc),
+			Expires:     unixTime(a.Expires, loc),
+			URI:         a.URI,
+		})
+	}
+	return s
+}
+
+func (db DataBlock) snapshot(loc *time.Location) []SnapshotPoint {
+	if len(db.Data) == 0 {
+		return nil
+	}
+	points := make([]SnapshotPoint, len(db.Data))
+	for i, dp := range db.Data {
+		points[i] = dp.snapshot(loc)
+	}
+	return points
+}
+
+// snapshot converts dp, falling back to the legacy Max/Min fields when the
+// High/Low ones are absent.
+func (dp DataPoint) snapshot(loc *time.Location) SnapshotPoint {
+	p := SnapshotPoint{
+		Time:                        unixTime(dp.Time, loc),
+		Summary:                     dp.Summary,
+		Icon:                        Icon(dp.Icon),
+		SunriseTime:                 unixTime(dp.SunriseTime, loc),
+		SunsetTime:                  unixTime(dp.SunsetTime, loc),
+		MoonPhase:                   dp.MoonPhase,
+		PrecipIntensity:             dp.PrecipIntensity,
+		PrecipIntensityMax:          dp.PrecipIntensityMax,
+		PrecipIntensityMaxTime:      unixTime(dp.PrecipIntensityMaxTime, loc),
+		PrecipProbability:           dp.PrecipProbability,
+		PrecipType:                  PrecipType(dp.PrecipType),
+		PrecipAccumulation:          dp.PrecipAccumulation,
+		Temperature:                 dp.Temperature,
+		ApparentTemperature:         dp.ApparentTemperature,
+		TemperatureHigh:             dp.TemperatureHigh,
+		TemperatureHighTime:         unixTime(dp.TemperatureHighTime, loc),
+		TemperatureLow:              dp.TemperatureLow,
+		TemperatureLowTime:          unixTime(dp.TemperatureLowTime, loc),
+		ApparentTemperatureHigh:     dp.ApparentTemperatureHigh,
+		ApparentTemperatureHighTime: unixTime(dp.ApparentTemperatureHighTime, loc),
+		ApparentTemperatureLow:      dp.ApparentTemperatureLow,
+		ApparentTemperatureLowTime:  unixTime(dp.ApparentTemperatureLowTime, loc),
+		DewPoint:                    dp.DewPoint,
+		Humidity:                    dp.Humidity,
+		Pressure:                    dp.Pressure,
+		WindSpeed:                   dp.WindSpeed,
+		WindGust:                    dp.WindGust,
+		WindGustTime:                unixTime(dp.WindGustTime, loc),
+		WindBearing:                 dp.WindBearing,
+		CloudCover:                  dp.CloudCover,
+		UVIndex:                     dp.UVIndex,
+		UVIndexTime:                 unixTime(float64(dp.UVIndexTime), loc),
+		Ozone:                       dp.Ozone,
+		Visibility:                  dp.Visibility,
+		Smoke:                       dp.Smoke,
+		LiquidAccumulation:          dp.LiquidAccumulation,
+		SnowAccumulation:            dp.SnowAccumulation,
+		IceAccumulation:             dp.IceAccumulation,
+	}
+	if dp.TemperatureHigh == 0 && dp.TemperatureHighTime == 0 {
+		p.TemperatureHigh = dp.TemperatureMax
+		p.TemperatureHighTime = unixTime(dp.TemperatureMaxTime, loc)
+	}
+	if dp.TemperatureLow == 0 && dp.TemperatureLowTime == 0 {
+		p.TemperatureLow = dp.TemperatureMin
+		p.TemperatureLowTime = unixTime(dp.TemperatureMinTime, loc)
+	}
+	if dp.ApparentTemperatureHigh == 0 && dp.ApparentTemperatureHighTime == 0 {
+		p.ApparentTemperatureHigh = dp.ApparentTemperatureMax
+		p.ApparentTemperatureHighTime = unixTime(dp.ApparentTemperatureMaxTime, loc)
+	}
+	if dp.ApparentTemperatureLow == 0 && dp.ApparentTemperatureLowTime == 0 {
+		p.ApparentTemperatureLow = dp.ApparentTemperatureMin
+		p.ApparentTemperatureLowTime = unixTime(dp.ApparentTemperatureMinTime, loc)
+	}
+	return p
+}
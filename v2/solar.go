@@ -0,0 +1,40 @@
+package forecast
+
+import "math"
+
+// SolarPotential estimates relative solar generation for the point on a 0–1 scale.
+// It is an approximation, not an irradiance model: the UV index, scaled so that 10 or more
+// counts as full sun, stands in for the sun's elevation, and cloud cover reduces it by the
+// Kasten–Czeplak factor 1 - 0.75·cloudCover^3.4.
+func (dp DataPoint) SolarPotential() float64 {
+	sun := math.Min(float64(dp.UVIndex)/10, 1)
+	clouds := 1 - 0.75*math.Pow(dp.CloudCover, 3.4)
+	return sun * clouds
+}
+
+// DailySolarPotential estimates a day's solar generation as the equivalent number of hours
+// at full potential. Hourly points between the day's sunrise and sunset are summed when the
+// hourly block covers that period; otherwise the daily point's SolarPotential, which
+// reflects its peak UV index, is spread over the daylight hours along a sine-shaped curve
+// (averaging 2/π of the peak). It returns 0 when the day is out of range or has no sun times.
+func (f *Forecast) DailySolarPotential(dayIndex int) float64 {
+	if dayIndex < 0 || dayIndex >= len(f.Daily.Data) {
+		return 0
+	}
+	day := f.Daily.Data[dayIndex]
+	if day.SunriseTime == 0 || day.SunsetTime == 0 {
+		return 0
+	}
+	hourly := f.Hourly.Data
+	if len(hourly) > 0 && hourly[0].Time <= day.SunriseTime && hourly[len(hourly)-1].Time >= day.SunsetTime {
+		var total float64
+		for _, dp := range hourly {
+			if dp.Time >= day.SunriseTime && dp.Time < day.SunsetTime {
+				total += dp.SolarPotential()
+			}
+		}
+		return total
+	}
+	daylight := (day.SunsetTime - day.SunriseTime) / 3600
+	return day.SolarPotential() * daylight * 2 / math.Pi
+}
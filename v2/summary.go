@@ -0,0 +1,99 @@
+package forecast
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// GenerateSummary builds a plain summary of the point from its numeric fields, such as
+// "Partly cloudy with a high of 72°F and a 30% chance of rain", for providers that leave
+// Summary empty. It is a fallback: the API's own summaries describe timing and trends that
+// cannot be recovered from a single point, so prefer Summary when it is set.
+//
+// The sky is described from CloudCover as clear (below 0.2), partly cloudy (below 0.6),
+// mostly cloudy (below 0.9) or overcast. Daily points mention their high, other points
+// their temperature, in the point's units. A chance of precipitation is mentioned from 10%
+// upwards, naming PrecipType when known.
+func (dp DataPoint) GenerateSummary() string {
+	var sky string
+	switch {
+	case dp.CloudCover < 0.2:
+		sky = "Clear"
+	case dp.CloudCover < 0.6:
+		sky = "Partly cloudy"
+	case dp.CloudCover < 0.9:
+		sky = "Mostly cloudy"
+	default:
+		sky = "Overcast"
+	}
+
+	unit := string(dp.unitSet().temperature)
+	var clauses []string
+	switch {
+	case dp.isDaily() && dp.hasHigh():
+		clauses = append(clauses, "a high of "+FormatField("temperatureHigh", dp.High())+unit)
+	case !dp.isDaily() && (dp.Temperature != 0 || dp.Humidity != 0):
+		clauses = append(clauses, "a temperature of "+FormatField("temperature", dp.Temperature)+unit)
+	}
+	if dp.PrecipProbability >= 0.1 {
+		kind := dp.PrecipType
+		if kind == "" {
+			kind = "precipitation"
+		}
+		chance := FormatValue(math.Min(dp.PrecipProbability, 1)*100, 0)
+		clauses = append(clauses, "a "+chance+"% chance of "+kind)
+	}
+	if len(clauses) == 0 {
+		return sky
+	}
+	return sky + " with " + strings.Join(clauses, " and ")
+}
+
+// DefaultHeadlinePrecipProbability is the probability from which Headline treats
+// precipitation in the minutely block as imminent.
+const DefaultHeadlinePrecipProbability = 0.5
+
+// Headline is HeadlineWith using DefaultHeadlinePrecipProbability.
+func (f *Forecast) Headline() string {
+	return f.HeadlineWith(DefaultHeadlinePrecipProbability)
+}
+
+// HeadlineWith returns the single most useful line about the weather, like the header of the
+// Dark Sky app. When the minutely block shows precipitation within the hour with at least
+// precipProbability, it returns the minutely summary, such as "Rain starting in 20 min.",
+// or builds one like it if the provider sent none. Otherwise it falls back to the hourly
+// summary, then the current summary, then GenerateSummary for the current conditions,
+// skipping whatever is missing. It returns "" for a forecast with none of these.
+func (f *Forecast) HeadlineWith(precipProbability float64) string {
+	minutes := f.Minutely.Sorted().Data
+	for _, dp := range minutes {
+		if dp.PrecipIntensity <= 0 || dp.PrecipProbability < precipProbability {
+			continue
+		}
+		if f.Minutely.Summary != "" {
+			return f.Minutely.Summary
+		}
+		kind := "Precipitation"
+		if dp.PrecipType != "" {
+			kind = strings.ToUpper(dp.PrecipType[:1]) + dp.PrecipType[1:]
+		}
+		start := f.Currently.Time
+		if start == 0 {
+			start = minutes[0].Time
+		}
+		if mins := int(math.Round((dp.Time - start) / 60)); mins > 0 {
+			return kind + " starting in " + strconv.Itoa(mins) + " min."
+		}
+		return kind + " now."
+	}
+	switch {
+	case f.Hourly.Summary != "":
+		return f.Hourly.Summary
+	case f.Currently.Summary != "":
+		return f.Currently.Summary
+	case f.Currently.Time != 0:
+		return f.Currently.GenerateSummary()
+	}
+	return ""
+}
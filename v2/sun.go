@@ -0,0 +1,68 @@
+package forecast
+
+import "time"
+
+// GoldenHours approximates the photographers' golden hours of the given day of the daily
+// block as the first and last hour of daylight, in the forecast's time zone. The true
+// duration depends on latitude and season, since it is defined by the sun's elevation;
+// this approximation only uses the reported sunrise and sunset times.
+// ok is false when the day is out of range or lacks a sunrise or sunset, as during polar
+// day or night.
+func (f *Forecast) GoldenHours(dayIndex int) (morningStart, morningEnd, eveningStart, eveningEnd time.Time, ok bool) {
+	if dayIndex < 0 || dayIndex >= len(f.Daily.Data) {
+		return
+	}
+	dp := f.Daily.Data[dayIndex]
+	if dp.SunriseTime == 0 || dp.SunsetTime == 0 {
+		return
+	}
+	loc := f.Location()
+	sunrise, sunset := unixTime(dp.SunriseTime, loc), unixTime(dp.SunsetTime, loc)
+	return sunrise, sunrise.Add(time.Hour), sunset.Add(-time.Hour), sunset, true
+}
+
+// NextSunEvent returns the first sunrise or sunset in the daily block after now, in the
+// forecast's time zone, with kind set to "sunrise" or "sunset". After the last sunset of a
+// day this is the next day's sunrise. ok is false when the block holds no later sun event.
+func (f *Forecast) NextSunEvent(now time.Time) (kind string, at time.Time, ok bool) {
+	loc := f.Location()
+	for _, dp := range f.Daily.Data {
+		for _, e := range []struct {
+			kind string
+			t    float64
+		}{{"sunrise", dp.SunriseTime}, {"sunset", dp.SunsetTime}} {
+			if e.t == 0 {
+				continue
+			}
+			t := unixTime(e.t, loc)
+			if t.After(now) && (!ok || t.Before(at)) {
+				kind, at, ok = e.kind, t, true
+			}
+		}
+	}
+	return kind, at, ok
+}
+
+// UVProtectionWindow returns the span of the given day of the daily block during which the
+// hourly UV index reaches threshold, from the start of the first such hour to the end of the
+// last, in the forecast's time zone. The hourly block only covers the first two days or so.
+// ok is false when the day is out of range or the UV index never reaches the threshold.
+func (f *Forecast) UVProtectionWindow(threshold int, dayIndex int) (start, end time.Time, ok bool) {
+	if dayIndex < 0 || dayIndex >= len(f.Daily.Data) {
+		return
+	}
+	loc := f.Location()
+	dayStart := unixTime(f.Daily.Data[dayIndex].Time, loc)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+	for _, dp := range f.Hourly.Data {
+		t := unixTime(dp.Time, loc)
+		if t.Before(dayStart) || !t.Before(dayEnd) || dp.UVIndex < threshold {
+			continue
+		}
+		if !ok {
+			start, ok = t, true
+		}
+		end = t.Add(time.Hour)
+	}
+	return start, end, ok
+}
@@ -0,0 +1,160 @@
+package forecast
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// ErrMissingTZData is returned, wrapped with the zone name, when a forecast's Timezone cannot
+// be loaded because the system has no time zone database, as on scratch or distroless
+// container images. Importing time/tzdata in the main package embeds the database in the
+// binary; alternatively, mount /usr/share/zoneinfo or set ZONEINFO.
+var ErrMissingTZData = errors.New("forecast: time zone database not found; import time/tzdata or mount /usr/share/zoneinfo")
+
+// loadZone loads a time zone by name. It is a variable so tests can simulate a system
+// without a time zone database.
+var loadZone = time.LoadLocation
+
+// tzdataMissing reports whether the time zone database is unavailable, probing a zone that
+// any complete database contains. It is only called once loading a zone has failed.
+func tzdataMissing() bool {
+	_, err := loadZone("America/New_York")
+	return err != nil
+}
+
+// unixTime converts an API timestamp (seconds since the epoch) to a time.Time in loc.
+// A zero timestamp means the field was absent and yields the zero time.Time.
+func unixTime(sec float64, loc *time.Location) time.Time {
+	if sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(sec), 0).In(loc)
+}
+
+// millisecondThreshold is the magnitude above which a timestamp is taken to be in
+// milliseconds. As seconds it would lie in the year 5138; as milliseconds it is in 1973, so
+// every plausible timestamp of either kind is classified correctly.
+const millisecondThreshold = 1e11
+
+// seconds returns the timestamp v in seconds, converting it from milliseconds if its
+// magnitude shows it to be one.
+func seconds(v float64) float64 {
+	if math.Abs(v) >= millisecondThreshold {
+		return math.Trunc(v / 1000)
+	}
+	return v
+}
+
+// normalizeTimes converts every timestamp of the forecast that some compatible providers
+// report in milliseconds to seconds, as the API reports them.
+func (f *Forecast) normalizeTimes() {
+	f.Currently.normalizeTimes()
+	for _, db := range []*DataBlock{&f.Minutely, &f.Hourly, &f.Daily} {
+		for i := range db.Data {
+			db.Data[i].normalizeTimes()
+		}
+	}
+	for i := range f.Alerts {
+		f.Alerts[i].Time = seconds(f.Alerts[i].Time)
+		f.Alerts[i].Expires = seconds(f.Alerts[i].Expires)
+	}
+}
+
+func (dp *DataPoint) normalizeTimes() {
+	for _, t := range []*float64{
+		&dp.Time,
+		&dp.SunriseTime,
+		&dp.SunsetTime,
+		&dp.PrecipIntensityMaxTime,
+		&dp.TemperatureLowTime,
+		&dp.TemperatureHighTime,
+		&dp.ApparentTemperatureHighTime,
+		&dp.ApparentTemperatureLowTime,
+		&dp.TemperatureMinTime,
+		&dp.TemperatureMaxTime,
+		&dp.ApparentTemperatureMinTime,
+		&dp.ApparentTemperatureMaxTime,
+		&dp.WindGustTime,
+	} {
+		*t = seconds(*t)
+	}
+	dp.UVIndexTime = int(seconds(float64(dp.UVIndexTime)))
+}
+
+// Location returns the forecast's time zone. If Timezone cannot be loaded, for example
+// because the time zone database is missing (see ErrMissingTZData), a fixed zone derived
+// from Offset is returned instead, so helpers built on it still produce usable times.
+func (f *Forecast) Location() *time.Location {
+	loc, _ := f.loadLocation()
+	return loc
+}
+
+// loadLocation is like Location but also returns the error that caused it to fall back to
+// the fixed Offset zone, if any. A missing time zone database is reported as
+// ErrMissingTZData rather than as an unknown zone.
+func (f *Forecast) loadLocation() (*time.Location, error) {
+	fixed := time.FixedZone(f.Timezone, int(f.Offset*3600))
+	if f.Timezone == "" {
+		return fixed, nil
+	}
+	loc, err := loadZone(f.Timezone)
+	if err != nil {
+		if tzdataMissing() {
+			return fixed, fmt.Errorf("%w: loading %q", ErrMissingTZData, f.Timezone)
+		}
+		return fixed, err
+	}
+	return loc, nil
+}
+
+// LocalizeTimes returns a copy of the forecast in which the LocalTime field of every data
+// point holds its Time in the forecast's time zone. If the zone cannot be loaded, for
+// example because tzdata is missing, the fixed Offset zone is used; the copy is still
+// returned, together with the error that caused the fallback.
+func (f *Forecast) LocalizeTimes() (*Forecast, error) {
+	loc, err := f.loadLocation()
+	c := f.Clone()
+	c.Currently.LocalTime = unixTime(c.Currently.Time, loc)
+	for _, db := range []*DataBlock{&c.Minutely, &c.Hourly, &c.Daily} {
+		for i := range db.Data {
+			db.Data[i].LocalTime = unixTime(db.Data[i].Time, loc)
+		}
+	}
+	return c, err
+}
+
+// CoveredRange returns the times of the earliest and latest data points across the current
+// conditions and the minutely, hourly and daily blocks, in the forecast's time zone. The
+// end is the start of the last point, not the end of the hour or day it describes. ok is
+// false when the forecast has no data points.
+func (f *Forecast) CoveredRange() (start, end time.Time, ok bool) {
+	var lo, hi float64
+	for _, b := range []DataBlockType{Currently, Minutely, Hourly, Daily} {
+		for _, dp := range f.points(b) {
+			if dp.Time == 0 {
+				continue
+			}
+			if !ok || dp.Time < lo {
+				lo = dp.Time
+			}
+			if !ok || dp.Time > hi {
+				hi = dp.Time
+			}
+			ok = true
+		}
+	}
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+	loc := f.Location()
+	return unixTime(lo, loc), unixTime(hi, loc), true
+}
+
+// sameDate reports whether a and b fall on the same calendar day in a's location.
+func sameDate(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.In(a.Location()).Date()
+	return ay == by && am == bm && ad == bd
+}
@@ -0,0 +1,80 @@
+package forecast
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// withoutTZData makes every zone but UTC fail to load, as on a system without a time zone
+// database, for the duration of the test.
+func withoutTZData(t *testing.T) {
+	t.Helper()
+	orig := loadZone
+	loadZone = func(name string) (*time.Location, error) {
+		if name == "UTC" {
+			return time.UTC, nil
+		}
+		return nil, errors.New("unknown time zone " + name)
+	}
+	t.Cleanup(func() { loadZone = orig })
+}
+
+func TestMissingTZData(t *testing.T) {
+	withoutTZData(t)
+	f := &Forecast{Timezone: "Europe/Paris", Offset: 1, Currently: DataPoint{Time: 1450000000}}
+
+	_, err := f.LocalizeTimes()
+	if !errors.Is(err, ErrMissingTZData) {
+		t.Fatalf("got error %v, want %v", err, ErrMissingTZData)
+	}
+	if _, offset := time.Unix(1450000000, 0).In(f.Location()).Zone(); offset != 3600 {
+		t.Errorf("fallback zone has offset %d, want 3600", offset)
+	}
+}
+
+func TestUnknownZoneWithTZData(t *testing.T) {
+	orig := loadZone
+	loadZone = func(name string) (*time.Location, error) {
+		if name == "Nowhere/Atlantis" {
+			return nil, errors.New("unknown time zone " + name)
+		}
+		return time.UTC, nil
+	}
+	t.Cleanup(func() { loadZone = orig })
+
+	f := &Forecast{Timezone: "Nowhere/Atlantis", Offset: -3}
+	_, err := f.LocalizeTimes()
+	if err == nil || errors.Is(err, ErrMissingTZData) {
+		t.Errorf("got error %v, want an unknown zone error", err)
+	}
+}
+
+func TestFromJSONMillisecondTimes(t *testing.T) {
+	body := []byte(`{
+		"currently": {"time": 1450000000000, "temperature": 50},
+		"hourly": {"data": [{"time": 1450000000000}, {"time": 1450003600000}]},
+		"daily": {"data": [{"time": 1449964800000, "sunriseTime": 1449990000000, "uvIndexTime": 1450008000000}]},
+		"alerts": [{"title": "Wind", "time": 1450000000000, "expires": 1450036000000}]
+	}`)
+	f, err := FromJSON(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := []float64{
+		f.Currently.Time,
+		f.Hourly.Data[0].Time,
+		f.Hourly.Data[1].Time,
+		f.Daily.Data[0].Time,
+		f.Daily.Data[0].SunriseTime,
+		float64(f.Daily.Data[0].UVIndexTime),
+		f.Alerts[0].Time,
+		f.Alerts[0].Expires,
+	}
+	want := []float64{1450000000, 1450000000, 1450003600, 1449964800, 1449990000, 1450008000, 1450000000, 1450036000}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("timestamp %d: got %v, want %v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package forecast
+
+import (
+	"net/http"
+	"time"
+)
+
+// OptimizedTransport returns an HTTP transport tuned for sending many requests to a single
+// API host, for use in Client.HTTPClient when polling at high volume. It starts from
+// http.DefaultTransport's settings but keeps up to 32 idle connections to the host instead
+// of 2, so that concurrent requests, such as those of GetBatchStream and GetHistory, reuse
+// connections rather than repeating the TCP and TLS handshakes, and holds them open for two
+// minutes between polls.
+func OptimizedTransport() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.MaxIdleConns = 64
+	t.MaxIdleConnsPerHost = 32
+	t.IdleConnTimeout = 2 * time.Minute
+	return t
+}
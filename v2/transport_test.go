@@ -0,0 +1,62 @@
+package forecast
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// benchmarkTransport polls a TLS server through t with bursts of concurrent requests, as
+// GetBatchStream does, and reports how many connections, each with its own TCP and TLS
+// handshake, the server accepted per burst.
+func benchmarkTransport(b *testing.B, t *http.Transport) {
+	const burst = 16
+	var conns atomic.Int64
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A little latency keeps the requests of a burst overlapping.
+		time.Sleep(time.Millisecond)
+		w.Write([]byte(`{"latitude":1,"longitude":2}`))
+	}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	srv.StartTLS()
+	defer srv.Close()
+	t.TLSClientConfig = srv.Client().Transport.(*http.Transport).TLSClientConfig.Clone()
+	defer t.CloseIdleConnections()
+	client := &http.Client{Transport: t}
+
+	for b.Loop() {
+		var wg sync.WaitGroup
+		for i := 0; i < burst; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				res, err := client.Get(srv.URL)
+				if err != nil {
+					b.Error(err)
+					return
+				}
+				io.Copy(io.Discard, res.Body)
+				res.Body.Close()
+			}()
+		}
+		wg.Wait()
+	}
+	b.ReportMetric(float64(conns.Load())/float64(b.N), "conns/op")
+}
+
+func BenchmarkDefaultTransport(b *testing.B) {
+	benchmarkTransport(b, http.DefaultTransport.(*http.Transport).Clone())
+}
+
+func BenchmarkOptimizedTransport(b *testing.B) {
+	benchmarkTransport(b, OptimizedTransport())
+}
@@ -0,0 +1,305 @@
+package forecast
+
+// UnitKind is the unit a single measured dimension (temperature, speed, distance,
+// pressure or precipitation) is expressed in.
+type UnitKind string
+
+// Temperature units.
+const (
+	Fahrenheit UnitKind = "°F"
+	Celsius    UnitKind = "°C"
+)
+
+// Speed units.
+const (
+	MilesPerHour      UnitKind = "mph"
+	MetersPerSecond   UnitKind = "m/s"
+	KilometersPerHour UnitKind = "km/h"
+)
+
+// Distance units.
+const (
+	Miles      UnitKind = "mi"
+	Kilometers UnitKind = "km"
+)
+
+// Pressure units. Millibars and hectopascals are numerically identical.
+const (
+	Millibars       UnitKind = "mb"
+	Hectopascals    UnitKind = "hPa"
+	InchesOfMercury UnitKind = "inHg"
+)
+
+// Precipitation units. Inches means intensity in in/h and accumulation in inches;
+// Millimeters means intensity in mm/h and accumulation in centimeters, as the API reports them.
+const (
+	Inches      UnitKind = "in"
+	Millimeters UnitKind = "mm"
+)
+
+// toBase holds the factor converting each non-temperature unit to the base unit of its
+// dimension: m/s, km, hPa and mm.
+var toBase = map[UnitKind]float64{
+	MetersPerSecond:   1,
+	KilometersPerHour: 1 / 3.6,
+	MilesPerHour:      0.44704,
+	Kilometers:        1,
+	Miles:             1.609344,
+	Hectopascals:      1,
+	Millibars:         1,
+	InchesOfMercury:   33.8639,
+	Millimeters:       1,
+	Inches:            25.4,
+}
+
+// dimensions maps each unit to the dimension it measures.
+var dimensions = map[UnitKind]string{
+	Fahrenheit:        "temperature",
+	Celsius:           "temperature",
+	MilesPerHour:      "speed",
+	MetersPerSecond:   "speed",
+	KilometersPerHour: "speed",
+	Miles:             "distance",
+	Kilometers:        "distance",
+	Millibars:         "pressure",
+	Hectopascals:      "pressure",
+	InchesOfMercury:   "pressure",
+	Inches:            "precipitation",
+	Millimeters:       "precipitation",
+}
+
+// convertUnit converts v from one unit to another of the same dimension.
+func convertUnit(v float64, from, to UnitKind) float64 {
+	switch {
+	case from == to:
+		return v
+	case from == Fahrenheit && to == Celsius:
+		return (v - 32) * 5 / 9
+	case from == Celsius && to == Fahrenheit:
+		return v*9/5 + 32
+	}
+	return v * toBase[from] / toBase[to]
+}
+
+// unitSet records the unit of each dimension of a data point's values.
+type unitSet struct {
+	temperature UnitKind
+	speed       UnitKind
+	distance    UnitKind
+	pressure    UnitKind
+	precip      UnitKind
+}
+
+// uk2 is the name the API uses for UK units in responses and, since "uk" was deprecated,
+// in requests.
+const uk2 Units = "uk2"
+
+// unitSet returns the per-dimension units of the unit system u.
+// Unknown systems are treated as US, the API default.
+func (u Units) unitSet() unitSet {
+	switch u {
+	case SI:
+		return unitSet{Celsius, MetersPerSecond, Kilometers, Hectopascals, Millimeters}
+	case CA:
+		return unitSet{Celsius, KilometersPerHour, Kilometers, Hectopascals, Millimeters}
+	case UK, uk2:
+		return unitSet{Celsius, MilesPerHour, Miles, Hectopascals, Millimeters}
+	}
+	return unitSet{Fahrenheit, MilesPerHour, Miles, Millibars, Inches}
+}
+
+// system returns the unit system whose units are exactly s, if there is one.
+func (s unitSet) system() (Units, bool) {
+	for _, u := range []Units{US, SI, CA, UK} {
+		if u.unitSet() == s {
+			return u, true
+		}
+	}
+	return "", false
+}
+
+// toFahrenheit converts a temperature in s to degrees Fahrenheit.
+func (s unitSet) toFahrenheit(v float64) float64 {
+	return convertUnit(v, s.temperature, Fahrenheit)
+}
+
+// toMPH converts a wind speed in s to miles per hour.
+func (s unitSet) toMPH(v float64) float64 {
+	return convertUnit(v, s.speed, MilesPerHour)
+}
+
+// ResolvedUnits returns the unit system the forecast's values are expressed in, as reported
+// by Flags.Units. The API defaults to US units, so that is assumed when the flag is missing.
+// The API reports UK units as "uk2", which is returned as UK.
+func (f *Forecast) ResolvedUnits() Units {
+	switch u := Units(f.Flags.Units); u {
+	case "":
+		return US
+	case uk2:
+		return UK
+	default:
+		return u
+	}
+}
+
+// unitSet returns the per-dimension units of the forecast's values.
+func (f *Forecast) unitSet() unitSet {
+	if f.units != (unitSet{}) {
+		return f.units
+	}
+	return f.ResolvedUnits().unitSet()
+}
+
+// TempUnit returns the unit of the forecast's temperatures, such as "°F" or "°C", for use
+// in labels and suffixes. Like WindUnit, DistanceUnit and PressureUnit it reflects the
+// forecast's actual units, which for UK mix metric temperatures with miles per hour and
+// miles.
+func (f *Forecast) TempUnit() string {
+	return string(f.unitSet().temperature)
+}
+
+// WindUnit returns the unit of the forecast's wind speeds: "mph" for US and UK, "m/s" for SI
+// and "km/h" for CA.
+func (f *Forecast) WindUnit() string {
+	return string(f.unitSet().speed)
+}
+
+// DistanceUnit returns the unit of the forecast's distances, such as visibility and the
+// distance to the nearest storm: "mi" for US and UK and "km" otherwise.
+func (f *Forecast) DistanceUnit() string {
+	return string(f.unitSet().distance)
+}
+
+// PressureUnit returns the unit of the forecast's pressures: "mb" for US and "hPa" otherwise.
+func (f *Forecast) PressureUnit() string {
+	return string(f.unitSet().pressure)
+}
+
+// setUnits records s on the forecast and every one of its data points.
+func (f *Forecast) setUnits(s unitSet) {
+	f.units = s
+	f.Currently.units = s
+	for _, db := range []*DataBlock{&f.Minutely, &f.Hourly, &f.Daily} {
+		for i := range db.Data {
+			db.Data[i].units = s
+		}
+	}
+}
+
+// unitSet returns the per-dimension units of the point's values. Points that were not
+// decoded as part of a Forecast are assumed to use US units, the API default.
+func (dp DataPoint) unitSet() unitSet {
+	if dp.units != (unitSet{}) {
+		return dp.units
+	}
+	return US.unitSet()
+}
+
+// ConvertTo returns a copy of the forecast with every value converted to the unit system u.
+// Flags.Units of the copy is set to u. AUTO is not a concrete system and yields an
+// unconverted copy.
+func (f *Forecast) ConvertTo(u Units) *Forecast {
+	if u == AUTO {
+		return f.Clone()
+	}
+	c := f.convert(u.unitSet())
+	c.Flags.Units = string(u)
+	return c
+}
+
+// ConvertFields returns a copy of the forecast with temperatures, speeds, distances and
+// pressures each converted to the given unit. An empty UnitKind, or one belonging to a
+// different dimension, leaves that dimension unchanged. Precipitation is never converted.
+// If the resulting mix matches a unit system, Flags.Units of the copy is set to it;
+// otherwise Flags.Units keeps the value reported by the API.
+func (f *Forecast) ConvertFields(temp, speed, distance, pressure UnitKind) *Forecast {
+	s := f.unitSet()
+	if dimensions[temp] == "temperature" {
+		s.temperature = temp
+	}
+	if dimensions[speed] == "speed" {
+		s.speed = speed
+	}
+	if dimensions[distance] == "distance" {
+		s.distance = distance
+	}
+	if dimensions[pressure] == "pressure" {
+		s.pressure = pressure
+	}
+	c := f.convert(s)
+	if u, ok := s.system(); ok {
+		c.Flags.Units = string(u)
+	}
+	return c
+}
+
+// convert returns a copy of the forecast with every data point converted to s. Points
+// whose units were never recorded, as in a forecast built by hand, are taken to be in the
+// forecast's units rather than the US default of a lone point.
+func (f *Forecast) convert(s unitSet) *Forecast {
+	c := f.Clone()
+	own := f.unitSet()
+	convert := func(dp DataPoint) DataPoint {
+		if dp.units == (unitSet{}) {
+			dp.units = own
+		}
+		return dp.convert(s)
+	}
+	c.Currently = convert(c.Currently)
+	for _, db := range []*DataBlock{&c.Minutely, &c.Hourly, &c.Daily} {
+		for i := range db.Data {
+			db.Data[i] = convert(db.Data[i])
+		}
+	}
+	c.units = s
+	return c
+}
+
+// convert returns dp with its values converted from its own units to s.
+func (dp DataPoint) convert(s unitSet) DataPoint {
+	from := dp.unitSet()
+	// Absent values decode as zero, and temperature conversions are not proportional, so a
+	// zero temperature is only converted when the point shows it was actually reported.
+	daily := dp.isDaily()
+	for _, t := range []struct {
+		v       *float64
+		present bool
+	}{
+		{&dp.Temperature, !daily && dp.Humidity != 0},
+		{&dp.ApparentTemperature, !daily && dp.Humidity != 0},
+		{&dp.DewPoint, dp.Humidity != 0},
+		{&dp.TemperatureHigh, dp.TemperatureHighTime != 0},
+		{&dp.TemperatureLow, dp.TemperatureLowTime != 0},
+		{&dp.TemperatureMax, dp.TemperatureMaxTime != 0},
+		{&dp.TemperatureMin, dp.TemperatureMinTime != 0},
+		{&dp.ApparentTemperatureHigh, dp.ApparentTemperatureHighTime != 0},
+		{&dp.ApparentTemperatureLow, dp.ApparentTemperatureLowTime != 0},
+		{&dp.ApparentTemperatureMax, dp.ApparentTemperatureMaxTime != 0},
+		{&dp.ApparentTemperatureMin, dp.ApparentTemperatureMinTime != 0},
+	} {
+		if *t.v != 0 || t.present {
+			*t.v = convertUnit(*t.v, from.temperature, s.temperature)
+		}
+	}
+	dp.WindSpeed = convertUnit(dp.WindSpeed, from.speed, s.speed)
+	dp.WindGust = convertUnit(dp.WindGust, from.speed, s.speed)
+	dp.Visibility = convertUnit(dp.Visibility, from.distance, s.distance)
+	dp.Pressure = convertUnit(dp.Pressure, from.pressure, s.pressure)
+	dp.PrecipIntensity = convertUnit(dp.PrecipIntensity, from.precip, s.precip)
+	dp.PrecipIntensityMax = convertUnit(dp.PrecipIntensityMax, from.precip, s.precip)
+	// Metric accumulation is reported in centimeters rather than millimeters.
+	for _, v := range []*float64{&dp.PrecipAccumulation, &dp.LiquidAccumulation, &dp.SnowAccumulation, &dp.IceAccumulation} {
+		*v = convertUnit(*v*accumulationScale(from.precip), from.precip, s.precip) / accumulationScale(s.precip)
+	}
+	dp.units = s
+	return dp
+}
+
+// accumulationScale converts a precipitation accumulation in unit u to the scale of its
+// intensity unit.
+func accumulationScale(u UnitKind) float64 {
+	if u == Millimeters {
+		return 10
+	}
+	return 1
+}
@@ -0,0 +1,41 @@
+package forecast
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+const siBody = `{"latitude":1,"longitude":2,"flags":{"units":"si"},
+	"currently":{"time":1450000000,"temperature":35,"humidity":0.4},
+	"hourly":{"data":[{"time":1450000000,"temperature":35,"humidity":0.4}]}}`
+
+func TestUnmarshalJSONRecordsUnits(t *testing.T) {
+	var f Forecast
+	if err := json.Unmarshal([]byte(siBody), &f); err != nil {
+		t.Fatal(err)
+	}
+	if got := f.TempUnit(); got != "°C" {
+		t.Errorf("TempUnit() = %q, want °C", got)
+	}
+	if got := f.Currently.TemperatureInK(); math.Abs(got-308.15) > 1e-9 {
+		t.Errorf("TemperatureInK() = %v, want 308.15", got)
+	}
+	if got := f.Currently.ComfortLevel(); got == "cold" {
+		t.Errorf("ComfortLevel() = %q for 35°C", got)
+	}
+	us := f.ConvertTo(US)
+	if got := us.Hourly.Data[0].Temperature; math.Abs(got-95) > 1e-9 {
+		t.Errorf("converted temperature = %v, want 95", got)
+	}
+}
+
+func TestConvertHandBuiltForecast(t *testing.T) {
+	f := &Forecast{
+		Flags:     Flags{Units: string(SI)},
+		Currently: DataPoint{Time: 1450000000, Temperature: 35, Humidity: 0.4},
+	}
+	if got := f.ConvertTo(US).Currently.Temperature; math.Abs(got-95) > 1e-9 {
+		t.Errorf("converted temperature = %v, want 95", got)
+	}
+}
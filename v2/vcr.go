@@ -0,0 +1,168 @@
+package forecast
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+// ErrCassetteMiss is returned, wrapped with the request, by a replaying Recorder when its
+// cassette holds no matching response.
+var ErrCassetteMiss = errors.New("forecast: no recorded response for request")
+
+// RecorderMode selects whether a Recorder talks to the network.
+type RecorderMode int
+
+const (
+	// Replay serves responses from the cassette only.
+	Replay RecorderMode = iota
+	// Record sends requests over the network and saves each response to the cassette.
+	Record
+)
+
+// Interaction is one request and its response as stored in a cassette.
+type Interaction struct {
+	Method     string      `json:"method"`
+	URL        string      `json:"url"`
+	StatusCode int         `json:"status"`
+	Header     http.Header `json:"header"`
+	Body       string      `json:"body"`
+}
+
+// Recorder is an http.RoundTripper that records API responses to a cassette file and
+// replays them, for deterministic tests that run offline and use no quota. Use it as the
+// Transport of Client.HTTPClient. The API keys given to NewRecorder are replaced by
+// "REDACTED" in stored URLs, and requests are matched on their redacted method and URL, so
+// a cassette recorded with a real key can be replayed with any of the listed keys, such
+// as a placeholder used in tests. Repeated identical requests replay their recorded
+// responses in order, the last one being reused once the others are exhausted.
+type Recorder struct {
+	// Transport sends requests in Record mode. Defaults to http.DefaultTransport.
+	Transport http.RoundTripper
+
+	path   string
+	mode   RecorderMode
+	keys   []string
+	mu     sync.Mutex
+	tape   []Interaction
+	served map[string]int
+}
+
+// NewRecorder returns a Recorder for the cassette at path. In Replay mode the cassette is
+// loaded immediately; in Record mode it is created, replacing any existing file, and
+// rewritten after every response.
+func NewRecorder(path string, mode RecorderMode, keys ...string) (*Recorder, error) {
+	r := &Recorder{path: path, mode: mode, keys: keys, served: make(map[string]int)}
+	if mode == Record {
+		return r, r.save()
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &r.tape); err != nil {
+		return nil, fmt.Errorf("forecast: reading cassette %s: %w", path, err)
+	}
+	return r, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	url := r.redact(req.URL.String())
+	if r.mode == Replay {
+		return r.replay(req, url)
+	}
+
+	t := r.Transport
+	if t == nil {
+		t = http.DefaultTransport
+	}
+	res, err := t.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	// Redaction can change the body's length, so the replayed response sets its own.
+	header := res.Header.Clone()
+	header.Del("Content-Length")
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tape = append(r.tape, Interaction{
+		Method:     req.Method,
+		URL:        url,
+		StatusCode: res.StatusCode,
+		Header:     header,
+		Body:       r.redact(string(body)),
+	})
+	// A RoundTripper must not return a response together with an error.
+	if err := r.save(); err != nil {
+		res.Body.Close()
+		return nil, fmt.Errorf("forecast: writing cassette %s: %w", r.path, err)
+	}
+	return res, nil
+}
+
+func (r *Recorder) replay(req *http.Request, url string) (*http.Response, error) {
+	if req.Body != nil {
+		io.Copy(io.Discard, req.Body)
+		req.Body.Close()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id := req.Method + " " + url
+	var matches []Interaction
+	for _, in := range r.tape {
+		if in.Method == req.Method && in.URL == url {
+			matches = append(matches, in)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrCassetteMiss, id)
+	}
+	in := matches[min(r.served[id], len(matches)-1)]
+	r.served[id]++
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", in.StatusCode, http.StatusText(in.StatusCode)),
+		StatusCode:    in.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        in.Header.Clone(),
+		Body:          io.NopCloser(strings.NewReader(in.Body)),
+		ContentLength: int64(len(in.Body)),
+		Request:       req,
+	}, nil
+}
+
+// save writes the cassette. The caller must hold r.mu or have exclusive access to r.
+func (r *Recorder) save() error {
+	tape := r.tape
+	if tape == nil {
+		tape = []Interaction{}
+	}
+	b, err := json.MarshalIndent(tape, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, b, 0644)
+}
+
+// redact replaces the recorder's keys, raw and as escaped in URLs, in s.
+func (r *Recorder) redact(s string) string {
+	return redactKeys(s, r.keys)
+}
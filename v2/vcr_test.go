@@ -0,0 +1,41 @@
+package forecast
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestRecorderSaveError(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cassettes")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewRecorder(filepath.Join(dir, "tape.json"), Record, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: 200, Header: http.Header{}, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+	})
+	// Removing the directory makes writing the cassette fail.
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	req, _ := http.NewRequest("GET", "https://api.example.com/forecast/secret/1,2", nil)
+	res, err := r.RoundTrip(req)
+	if err == nil {
+		t.Fatal("got no error for a failed cassette write")
+	}
+	if res != nil {
+		t.Error("got a response together with an error")
+	}
+}